@@ -5,6 +5,7 @@ import (
 
 	"github.com/nvkp/turtle"
 	"github.com/nvkp/turtle/assert"
+	"github.com/nvkp/turtle/rdf"
 )
 
 type tripleWithAnnotationValues struct {
@@ -25,6 +26,14 @@ type tripleWithAnnotationPointers struct {
 	ObjectType *string `turtle:"objecttype"`
 }
 
+type tripleWithDirection struct {
+	Subject   string `turtle:"subject"`
+	Predicate string `turtle:"predicate"`
+	Object    string `turtle:"object"`
+	Label     string `turtle:"label"`
+	Direction string `turtle:"direction"`
+}
+
 var marshalWithAnnotationTestCases = map[string]struct {
 	triples   interface{}
 	expString string
@@ -74,6 +83,36 @@ var marshalWithAnnotationTestCases = map[string]struct {
 		expString: `<http://example.org/person/Mark_Twain> <http://example.org/relation/name> "Huckleberry Finn"^^xsd:string .
 `,
 	},
+	"one_triple_with_direction": {
+		triples: tripleWithDirection{
+			Subject:   "http://example.org/person/Naguib_Mahfouz",
+			Predicate: "http://example.org/relation/name",
+			Object:    "زقاق المدق",
+			Label:     "ar",
+			Direction: "rtl",
+		},
+		expString: `<http://example.org/person/Naguib_Mahfouz> <http://example.org/relation/name> "زقاق المدق"@ar--rtl .
+`,
+	},
+	"one_triple_with_invalid_direction": {
+		triples: tripleWithDirection{
+			Subject:   "http://example.org/person/Naguib_Mahfouz",
+			Predicate: "http://example.org/relation/name",
+			Object:    "زقاق المدق",
+			Label:     "ar",
+			Direction: "sideways",
+		},
+		expErr: rdf.ErrInvalidLanguageTag,
+	},
+	"one_triple_with_direction_without_language": {
+		triples: tripleWithDirection{
+			Subject:   "http://example.org/person/Naguib_Mahfouz",
+			Predicate: "http://example.org/relation/name",
+			Object:    "زقاق المدق",
+			Direction: "rtl",
+		},
+		expErr: rdf.ErrInvalidLanguageTag,
+	},
 	"slice_of_triples_with_annotations": {
 		triples: []tripleWithAnnotationValues{
 			{
@@ -154,3 +193,39 @@ func TestUnmarshalStructWithLabelPointer(t *testing.T) {
 	assert.NoError(t, err, "function Unmarshal should have returned no error")
 	assert.Equal(t, expected, target, "function Unmarshal should have assigned correct values to the target triple")
 }
+
+func TestMarshalUnmarshalLabelWithDirection(t *testing.T) {
+	triple := tripleWithAnnotationValues{
+		Subject:    "http://example.org/person/Naguib_Mahfouz",
+		Predicate:  "http://example.org/relation/name",
+		Object:     "زقاق المدق",
+		Label:      "ar--rtl",
+		ObjectType: "literal",
+	}
+
+	b, err := turtle.Marshal(triple)
+	assert.NoError(t, err, "function Marshal should have returned no error")
+	assert.Equal(t, `<http://example.org/person/Naguib_Mahfouz> <http://example.org/relation/name> "زقاق المدق"@ar--rtl .
+`, string(b), "function Marshal should have written the direction in the label")
+
+	var target tripleWithAnnotationValues
+	err = turtle.Unmarshal(b, &target)
+	assert.NoError(t, err, "function Unmarshal should have returned no error")
+	assert.Equal(t, triple, target, "function Unmarshal should have kept the direction in the label of a struct without a direction field")
+}
+
+func TestUnmarshalStructWithDirection(t *testing.T) {
+	var target tripleWithDirection
+	data := []byte(`<http://example.org/person/Naguib_Mahfouz> <http://example.org/relation/name> "زقاق المدق"@AR-eg--rtl .`)
+	expected := tripleWithDirection{
+		Subject:   "http://example.org/person/Naguib_Mahfouz",
+		Predicate: "http://example.org/relation/name",
+		Object:    "زقاق المدق",
+		Label:     "ar-EG",
+		Direction: "rtl",
+	}
+
+	err := turtle.Unmarshal(data, &target)
+	assert.NoError(t, err, "function Unmarshal should have returned no error")
+	assert.Equal(t, expected, target, "function Unmarshal should have split the normalized language tag and the direction")
+}
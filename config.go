@@ -8,18 +8,37 @@ import (
 	"github.com/nvkp/turtle/scanner"
 )
 
+// Config configures the base and prefixes used by the marshalling and
+// unmarshaling functions and how they treat the IRIs.
 type Config struct {
-	Base     string
-	Prefixes map[string]string
+	// If set, the unmarshaled IRIs are resolved against the base and
+	// expanded by the prefixes to absolute ones, and the marshalled IRIs
+	// are relativized against the base and compacted to prefixed names.
+	// Otherwise the IRIs are passed through exactly as written.
+	ResolveURLs bool
+	Base        string
+	Prefixes    map[string]string
+	// Same as Prefixes, but the marshalled @prefix forms keep
+	// the given order instead of being sorted by their names.
+	PrefixList graph.PrefixList
+	// If set, only the prefixes used by the marshalled
+	// triples are written as @prefix forms.
+	OmitUnusedPrefixes bool
+	// Order of the marshalled triples. Sorted alphabetically by default.
+	Order graph.Order
+	// Quotes of the marshalled literals. The long form is used
+	// for multiline literals by default.
+	Quotes graph.QuoteStyle
+	// Format of the marshalled and unmarshaled triples. Turtle by default.
+	Format graph.Format
+	// If set, unmarshaling fails on any data not following the Turtle
+	// grammar exactly instead of guessing what was meant.
+	Strict bool
 }
 
 func (c *Config) Marshal(v interface{}) ([]byte, error) {
-	g := graph.NewWithOptions(
-		graph.Options{
-			Base:     c.Base,
-			Prefixes: c.Prefixes,
-		})
-	if err := marshal(g, reflect.ValueOf(v)); err != nil {
+	g := graph.NewDatasetWithOptions(c.graphOptions())
+	if err := c.marshaller(g).marshal(reflect.ValueOf(v)); err != nil {
 		return nil, fmt.Errorf("marshal: %w", err)
 	}
 	return g.Bytes()
@@ -35,16 +54,56 @@ func (c *Config) Unmarshal(data []byte, v interface{}) error {
 		return ErrNoPointerValue
 	}
 
-	err := unmarshal(
-		scanner.NewWithOptions(data,
-			scanner.Options{
-				Base:     c.Base,
-				Prefixes: c.Prefixes,
-			}),
-		rv)
+	err := unmarshal(c.source(data), rv)
 	if err != nil {
-		return fmt.Errorf("unmarshal: %v", err)
+		return fmt.Errorf("unmarshal: %w", err)
 	}
 
 	return nil
 }
+
+func (c *Config) marshaller(g acceptor) *marshaller {
+	return &marshaller{
+		g:        g,
+		prefixes: c.prefixes(),
+		verbatim: !c.ResolveURLs,
+	}
+}
+
+func (c *Config) graphOptions() graph.Options {
+	return graph.Options{
+		Base:               c.Base,
+		Prefixes:           c.Prefixes,
+		PrefixList:         c.PrefixList,
+		OmitUnusedPrefixes: c.OmitUnusedPrefixes,
+		Verbatim:           !c.ResolveURLs,
+		Order:              c.Order,
+		Quotes:             c.Quotes,
+		Format:             c.Format,
+	}
+}
+
+func (c *Config) scannerOptions() scanner.Options {
+	return scanner.Options{
+		Base:     c.Base,
+		Prefixes: c.prefixes(),
+		Strict:   c.Strict,
+		Verbatim: !c.ResolveURLs,
+		TriG:     c.Format == graph.FormatTriG,
+	}
+}
+
+// prefixes merges the prefix list into the prefixes.
+func (c *Config) prefixes() map[string]string {
+	if len(c.PrefixList) == 0 {
+		return c.Prefixes
+	}
+
+	prefixes := c.PrefixList.Map()
+	for name, iri := range c.Prefixes {
+		if _, ok := prefixes[name]; !ok {
+			prefixes[name] = iri
+		}
+	}
+	return prefixes
+}
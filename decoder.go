@@ -0,0 +1,159 @@
+package turtle
+
+import (
+	"io"
+	"reflect"
+)
+
+// Decoder reads and decodes triples from an input stream one by one.
+// Unlike Unmarshal it does not need the whole Turtle document in memory,
+// the input is read only as far as it is needed for the next triple.
+type Decoder struct {
+	s      source
+	peeked bool
+	more   bool
+	// blankNodes holds the triples of the blank nodes
+	// nested in the decoded resources
+	blankNodes *resources
+}
+
+// NewDecoder returns a new decoder that reads from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return (&Config{ResolveURLs: true}).NewDecoder(r)
+}
+
+// NewDecoder returns a new decoder that reads from r
+// and applies the configured base and prefixes.
+func (c *Config) NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{
+		s: c.readerSource(r),
+	}
+}
+
+// More reports whether there is another triple in the input.
+func (d *Decoder) More() bool {
+	if !d.peeked {
+		d.more = d.s.Next()
+		d.peeked = true
+	}
+
+	if !d.more && d.blankNodes != nil {
+		return d.blankNodes.hasRoot()
+	}
+
+	return d.more
+}
+
+// Decode reads the next triple from its input and stores it in the value
+// pointed to by v. The value has to be a pointer to a struct with fields
+// annotated by the turtle tags the same way as for Unmarshal. When there
+// are no more triples in the input Decode returns io.EOF. When the input
+// is malformed it returns a *SyntaxError.
+//
+// A struct describing a resource is filled with all the consecutive
+// triples sharing the same subject. An Unmarshaler gets those triples
+// as they are.
+func (d *Decoder) Decode(v interface{}) error {
+	if v == nil {
+		return ErrNilValue
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr {
+		return ErrNoPointerValue
+	}
+
+	if !d.More() {
+		if err := d.s.Err(); err != nil {
+			return err
+		}
+		return io.EOF
+	}
+	d.peeked = false
+
+	if u, ok := unmarshaler[Unmarshaler](rv, unmarshalerType); ok {
+		return d.decodeTriples(u)
+	}
+
+	if isResource(rv.Elem().Type()) {
+		return d.decodeResource(rv.Elem())
+	}
+
+	err, _ := unmarshalStruct(d.s, rv.Elem())
+	return err
+}
+
+// decodeTriples reads the consecutive triples sharing
+// the subject and passes them to the Unmarshaler.
+func (d *Decoder) decodeTriples(u Unmarshaler) error {
+	var triples []Triple
+	for d.more {
+		t := d.s.TripleWithAnnotations()
+		if t[subject] != "" {
+			if len(triples) > 0 && t[subject] != triples[0].Subject {
+				// the triple of the next subject is left for the next call
+				break
+			}
+			triples = append(triples, newTriple(t))
+		}
+		d.peeked = false
+		d.More()
+	}
+
+	if err := d.s.Err(); err != nil {
+		return err
+	}
+
+	if len(triples) == 0 {
+		return io.EOF
+	}
+
+	return u.UnmarshalTurtle(triples)
+}
+
+// decodeResource reads the consecutive triples sharing
+// the subject and fills the resource struct with them.
+// The triples of the blank nodes are kept for the resources
+// referring to them. The blank nodes no resource refers to
+// are decoded at the end of the input.
+func (d *Decoder) decodeResource(v reflect.Value) error {
+	if d.blankNodes == nil {
+		d.blankNodes = newResources(d.s.Prefixes())
+	}
+	d.blankNodes.prefixes = d.s.Prefixes()
+
+	var r *resource
+	// the input can be exhausted already with only the blank nodes left
+	for d.more {
+		t := d.s.TripleWithAnnotations()
+		switch {
+		case t[subject] == "":
+			// skip the pragmas
+		case isBlankNode(t[subject]):
+			d.blankNodes.add(t)
+		case r == nil:
+			r = &resource{id: t[subject], triples: [][6]string{t}}
+			d.blankNodes.reference(t)
+		case t[subject] == r.id:
+			r.triples = append(r.triples, t)
+			d.blankNodes.reference(t)
+		default:
+			// the triple of the next subject is left for the next call
+			return d.blankNodes.unmarshal(v, r)
+		}
+		d.peeked = false
+		d.More()
+	}
+
+	if err := d.s.Err(); err != nil {
+		return err
+	}
+
+	if r == nil {
+		if r = d.blankNodes.nextRoot(); r == nil {
+			return io.EOF
+		}
+	}
+
+	return d.blankNodes.unmarshal(v, r)
+}
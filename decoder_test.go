@@ -0,0 +1,90 @@
+package turtle_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/nvkp/turtle"
+	"github.com/nvkp/turtle/assert"
+)
+
+func TestDecoder(t *testing.T) {
+	data := `@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+<http://example.org/alice> foaf:knows [ foaf:name "Bob" ] ;
+	foaf:interest ( <http://example.org/turtles> ) .`
+	expected := []triple{
+		{"_:b0", "http://xmlns.com/foaf/0.1/name", "Bob"},
+		{"http://example.org/alice", "http://xmlns.com/foaf/0.1/knows", "_:b0"},
+		{"_:b1", "http://www.w3.org/1999/02/22-rdf-syntax-ns#first", "http://example.org/turtles"},
+		{"_:b1", "http://www.w3.org/1999/02/22-rdf-syntax-ns#rest", "http://www.w3.org/1999/02/22-rdf-syntax-ns#nil"},
+		{"http://example.org/alice", "http://xmlns.com/foaf/0.1/interest", "_:b1"},
+	}
+
+	d := turtle.NewDecoder(iotest.OneByteReader(strings.NewReader(data)))
+
+	actual := make([]triple, 0)
+	for d.More() {
+		var target triple
+		err := d.Decode(&target)
+		assert.NoError(t, err, "method Decode should have returned no error")
+		actual = append(actual, target)
+	}
+
+	assert.Equal(t, expected, actual, "decoder should have decoded correct triples")
+
+	var target triple
+	err := d.Decode(&target)
+	assert.ErrorIs(t, err, io.EOF, "method Decode should have returned io.EOF at the end of the input")
+}
+
+func TestDecoderWithConfig(t *testing.T) {
+	data := `</person/Mark_Twain> </relation/author> books:Huckleberry_Finn .`
+	expected := tripleWithMetadata{
+		Base:      "http://example.org/",
+		Prefixes:  map[string]string{"books": "https://amazon.com/"},
+		Subject:   "http://example.org/person/Mark_Twain",
+		Predicate: "http://example.org/relation/author",
+		Object:    "https://amazon.com/Huckleberry_Finn",
+	}
+
+	c := turtle.Config{
+		ResolveURLs: true,
+		Base:        "http://example.org/",
+		Prefixes: map[string]string{
+			"books": "https://amazon.com/",
+		},
+	}
+
+	var target tripleWithMetadata
+	err := c.NewDecoder(strings.NewReader(data)).Decode(&target)
+	assert.NoError(t, err, "method Decode should have returned no error")
+	assert.Equal(t, expected, target, "decoder should have decoded a correct triple")
+}
+
+func TestDecoderInvalidTarget(t *testing.T) {
+	d := turtle.NewDecoder(strings.NewReader(`<a> <b> <c> .`))
+
+	err := d.Decode(nil)
+	assert.ErrorIs(t, err, turtle.ErrNilValue, "method Decode should have returned correct error")
+
+	err = d.Decode(triple{})
+	assert.ErrorIs(t, err, turtle.ErrNoPointerValue, "method Decode should have returned correct error")
+}
+
+func TestDecoderSyntaxError(t *testing.T) {
+	d := turtle.NewDecoder(strings.NewReader(`<a> <b> <c> . <d> ] .`))
+
+	var target triple
+	err := d.Decode(&target)
+	assert.NoError(t, err, "method Decode should have returned no error")
+
+	err = d.Decode(&target)
+	var syntaxErr *turtle.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Fatalf("expected a syntax error, got: %v", err)
+	}
+	assert.Equal(t, "]", syntaxErr.Token, "syntax error should contain the offending token")
+}
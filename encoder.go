@@ -0,0 +1,78 @@
+package turtle
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/nvkp/turtle/graph"
+)
+
+// ErrEncoderClosed is returned by Encoder when it is used after being closed
+var ErrEncoderClosed = errors.New("encoder is closed")
+
+// Encoder writes the triples extracted from the encoded values
+// to an output stream. The @base and @prefix forms are written
+// first, followed by the subject blocks.
+//
+// In the insertion order a subject block is written as soon as
+// a triple of another subject is encoded. In the default sorted
+// order the triples are kept until Flush or Close is called.
+type Encoder struct {
+	w      *graph.Writer
+	m      *marshaller
+	closed bool
+}
+
+// NewEncoder returns a new encoder that writes to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return (&Config{ResolveURLs: true}).NewEncoder(w)
+}
+
+// NewEncoder returns a new encoder that writes to w
+// and applies the configured base, prefixes and order.
+func (c *Config) NewEncoder(w io.Writer) *Encoder {
+	writer := graph.NewWriter(w, c.graphOptions())
+	return &Encoder{
+		w: writer,
+		m: c.marshaller(writer),
+	}
+}
+
+// Encode extracts the triples from v and writes the completed
+// subject blocks to the stream. It accepts the same values
+// as Marshal does.
+func (e *Encoder) Encode(v interface{}) error {
+	if e.closed {
+		return ErrEncoderClosed
+	}
+
+	if err := e.m.marshal(reflect.ValueOf(v)); err != nil {
+		return fmt.Errorf("encode: %w", err)
+	}
+
+	return nil
+}
+
+// Flush writes all the so far encoded triples to the stream.
+func (e *Encoder) Flush() error {
+	if e.closed {
+		return ErrEncoderClosed
+	}
+
+	return e.w.Flush()
+}
+
+// Close flushes the encoded triples and closes the encoder. It does
+// not close the underlying writer.
+func (e *Encoder) Close() error {
+	if e.closed {
+		return nil
+	}
+
+	err := e.w.Flush()
+	e.closed = true
+
+	return err
+}
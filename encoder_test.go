@@ -0,0 +1,82 @@
+package turtle_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nvkp/turtle"
+	"github.com/nvkp/turtle/assert"
+	"github.com/nvkp/turtle/graph"
+)
+
+func TestEncoder(t *testing.T) {
+	var b strings.Builder
+
+	c := turtle.Config{
+		ResolveURLs: true,
+		Base:        "http://example.org/",
+		Order:       graph.OrderInsertion,
+	}
+	e := c.NewEncoder(&b)
+
+	err := e.Encode(triple{
+		Subject:   "http://example.org/spiderman",
+		Predicate: "http://xmlns.com/foaf/0.1/name",
+		Object:    "Spiderman",
+	})
+	assert.NoError(t, err, "method Encode should have returned no error")
+	assert.Equal(t, "", b.String(), "encoder should not have written an incomplete subject block")
+
+	err = e.Encode([]triple{
+		{
+			Subject:   "http://example.org/green-goblin",
+			Predicate: "http://xmlns.com/foaf/0.1/name",
+			Object:    "Green Goblin",
+		},
+		{
+			Subject:   "http://example.org/green-goblin",
+			Predicate: "http://www.perceive.net/schemas/relationship/enemyOf",
+			Object:    "http://example.org/spiderman",
+		},
+	})
+	assert.NoError(t, err, "method Encode should have returned no error")
+	assert.Equal(t, `@base <http://example.org/> .
+<spiderman> <http://xmlns.com/foaf/0.1/name> "Spiderman" .
+`, b.String(), "encoder should have written the completed subject block")
+
+	err = e.Close()
+	assert.NoError(t, err, "method Close should have returned no error")
+	assert.Equal(t, `@base <http://example.org/> .
+<spiderman> <http://xmlns.com/foaf/0.1/name> "Spiderman" .
+<green-goblin> 
+	<http://xmlns.com/foaf/0.1/name> "Green Goblin" ;
+	<http://www.perceive.net/schemas/relationship/enemyOf> <spiderman> .
+`, b.String(), "encoder should have written all the subject blocks")
+
+	err = e.Encode(triple{})
+	assert.ErrorIs(t, err, turtle.ErrEncoderClosed, "method Encode should have returned correct error")
+}
+
+func TestEncoderSorted(t *testing.T) {
+	var b strings.Builder
+
+	e := turtle.NewEncoder(&b)
+
+	for _, tr := range []triple{
+		{"http://example.org/b", "http://example.org/p", "http://example.org/o"},
+		{"http://example.org/a", "http://example.org/p", "http://example.org/o"},
+	} {
+		err := e.Encode(tr)
+		assert.NoError(t, err, "method Encode should have returned no error")
+	}
+	assert.Equal(t, "", b.String(), "encoder should have kept the triples until flushed")
+
+	err := e.Flush()
+	assert.NoError(t, err, "method Flush should have returned no error")
+	assert.Equal(t, `<http://example.org/a> <http://example.org/p> <http://example.org/o> .
+<http://example.org/b> <http://example.org/p> <http://example.org/o> .
+`, b.String(), "encoder should have written sorted triples")
+
+	err = e.Encode(triple{Predicate: "http://example.org/p", Object: "http://example.org/o"})
+	assert.ErrorIs(t, err, turtle.ErrNoSubjectSpecified, "method Encode should have returned correct error")
+}
@@ -0,0 +1,79 @@
+package graph
+
+import (
+	"strings"
+
+	"github.com/nvkp/turtle/rdf"
+)
+
+// localEscapes lists the characters that can be escaped
+// by a backslash in the local part of a prefixed name.
+const localEscapes = "_~.-!$&'()*+,;=/?#@%"
+
+// compact returns the IRI as a prefixed name using the prefix with
+// the longest matching namespace. It fails if no namespace matches
+// or if the rest of the IRI cannot form a local name.
+func (g *Graph) compact(iri string) (string, bool) {
+	var prefix Prefix
+	for _, p := range g.prefixes {
+		if p.IRI != "" && strings.HasPrefix(iri, p.IRI) && len(p.IRI) > len(prefix.IRI) {
+			prefix = p
+		}
+	}
+
+	if prefix.IRI == "" {
+		return "", false
+	}
+
+	local, ok := escapeLocal(iri[len(prefix.IRI):])
+	if !ok {
+		return "", false
+	}
+
+	g.used[prefix.Name] = true
+	return prefix.Name + ":" + local, true
+}
+
+// escapeLocal escapes the characters of the local name that cannot
+// be part of it as they are. It fails if the local name contains
+// a character that cannot be escaped. Full stops are always escaped
+// so that they cannot be mistaken for the end of a statement.
+func escapeLocal(local string) (string, bool) {
+	var b strings.Builder
+	b.Grow(len(local))
+
+	for i, r := range local {
+		switch {
+		case r == '%' && isPercent(local[i+1:]):
+			b.WriteRune(r)
+		case isPNCharsU(r) || r == ':' || (r >= '0' && r <= '9'):
+			b.WriteRune(r)
+		case i > 0 && isPNChars(r):
+			b.WriteRune(r)
+		case strings.ContainsRune(localEscapes, r):
+			b.WriteRune(runeBackslash)
+			b.WriteRune(r)
+		default:
+			return "", false
+		}
+	}
+
+	return b.String(), true
+}
+
+func isPercent(str string) bool {
+	return len(str) >= 2 && isHex(str[0]) && isHex(str[1])
+}
+
+func isHex(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+func isPNCharsU(r rune) bool {
+	return rdf.IsPNCharsBase(r) || r == '_'
+}
+
+func isPNChars(r rune) bool {
+	return isPNCharsU(r) || r == '-' || (r >= '0' && r <= '9') || r == 0x00B7 ||
+		(r >= 0x0300 && r <= 0x036F) || (r >= 0x203F && r <= 0x2040)
+}
@@ -0,0 +1,89 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/nvkp/turtle/assert"
+)
+
+var compactTestCases = map[string]struct {
+	iri      string
+	expected string
+}{
+	"simple": {
+		iri:      "http://xmlns.com/foaf/0.1/name",
+		expected: "foaf:name",
+	},
+	"longest_namespace": {
+		iri:      "http://example.org/people/Mark_Twain",
+		expected: "people:Mark_Twain",
+	},
+	"shorter_namespace": {
+		iri:      "http://example.org/books/Huckleberry_Finn",
+		expected: "ex:books\\/Huckleberry_Finn",
+	},
+	"empty_local_name": {
+		iri:      "http://example.org/people/",
+		expected: "people:",
+	},
+	"leading_digit_and_hyphen": {
+		iri:      "http://example.org/people/1-2",
+		expected: "people:1-2",
+	},
+	"leading_hyphen": {
+		iri:      "http://example.org/people/-a-",
+		expected: "people:\\-a-",
+	},
+	"full_stops": {
+		iri:      "http://example.org/people/a.b.",
+		expected: "people:a\\.b\\.",
+	},
+	"reserved_characters": {
+		iri:      "http://example.org/people/a(b)?c=d&e",
+		expected: "people:a\\(b\\)\\?c\\=d\\&e",
+	},
+	"percent_encoding": {
+		iri:      "http://example.org/people/a%20b%",
+		expected: "people:a%20b\\%",
+	},
+	"unicode": {
+		iri:      "http://example.org/people/Čapek·ová",
+		expected: "people:Čapek·ová",
+	},
+	"not_escapable": {
+		iri:      "http://example.org/people/a[b]",
+		expected: "<http://example.org/people/a[b]>",
+	},
+	"no_namespace": {
+		iri:      "https://schema.org/name",
+		expected: "<https://schema.org/name>",
+	},
+}
+
+func TestCompact(t *testing.T) {
+	g := NewWithOptions(Options{
+		Prefixes: map[string]string{
+			"ex":     "http://example.org/",
+			"people": "http://example.org/people/",
+			"foaf":   "http://xmlns.com/foaf/0.1/",
+		},
+	})
+
+	for name, tc := range compactTestCases {
+		t.Run(name, func(t *testing.T) {
+			actual := g.sanitize(tc.iri, "iri", false)
+			assert.Equal(t, tc.expected, actual, "function should have returned correctly compacted IRI")
+		})
+	}
+}
+
+func TestCompactVerbatim(t *testing.T) {
+	g := NewWithOptions(Options{
+		Base:     "http://example.org/",
+		Prefixes: map[string]string{"people": "http://example.org/people/"},
+		Verbatim: true,
+	})
+
+	assert.Equal(t, "<http://example.org/people/Mark_Twain>", g.sanitize("http://example.org/people/Mark_Twain", "iri", false), "IRI should have been written as it is")
+	assert.Equal(t, "people:Mark_Twain", g.sanitize("people:Mark_Twain", "", false), "prefixed name should have been written as it is")
+}
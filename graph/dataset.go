@@ -0,0 +1,259 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/nvkp/turtle/rdf"
+)
+
+// ErrNamedGraphs is returned when the triples of named graphs
+// are to be written in a format without graph names.
+var ErrNamedGraphs = errors.New("named graphs not supported by the format")
+
+// Dataset is a buffer of a default graph and any number of named graphs.
+// It consumes triples one by one, either to the default graph or to
+// a named graph, and can return a byte slice containing N-Quads or TriG
+// data of all of them.
+type Dataset struct {
+	options Options
+	def     *Graph
+	named   map[string]*Graph
+	names   []string
+}
+
+// NewDataset returns a pointer to a new instance of graph.Dataset. No options are set.
+func NewDataset() *Dataset {
+	return NewDatasetWithOptions(Options{})
+}
+
+// NewDatasetWithOptions constructs a dataset with options to tweak its
+// behavior. The options apply to all of its graphs. See Options.
+func NewDatasetWithOptions(options Options) *Dataset {
+	return &Dataset{
+		options: options,
+		def:     NewWithOptions(options),
+		named:   make(map[string]*Graph),
+	}
+}
+
+// Graph returns the graph of the given name, the default graph for
+// an empty name. A named graph is created if there is none yet.
+func (d *Dataset) Graph(name string) *Graph {
+	if name == "" {
+		return d.def
+	}
+
+	g, ok := d.named[name]
+	if !ok {
+		g = NewWithOptions(d.options)
+		// the prefixes can be limited to the ones already written
+		g.prefixes = d.def.prefixes
+		d.named[name] = g
+		d.names = append(d.names, name)
+	}
+
+	return g
+}
+
+// Names returns the names of the named graphs, sorted alphabetically
+// unless the insertion order is set in the dataset's options.
+func (d *Dataset) Names() []string {
+	names := make([]string, len(d.names))
+	copy(names, d.names)
+
+	if d.options.Order == OrderSorted {
+		sort.Strings(names)
+	}
+
+	return names
+}
+
+// Accept stores a new triple to the default graph.
+func (d *Dataset) Accept(t [3]string) error {
+	return d.def.Accept(t)
+}
+
+// AcceptWithAnnotations stores a new triple with eventual label and data
+// type of the object literal to the default graph.
+func (d *Dataset) AcceptWithAnnotations(t [6]string) error {
+	return d.def.AcceptWithAnnotations(t)
+}
+
+// AcceptInGraph stores a new triple with eventual label and data type
+// of the object literal to the graph of the given name, the default
+// graph for an empty name.
+func (d *Dataset) AcceptInGraph(name string, t [6]string) error {
+	if err := checkIRI(name); err != nil {
+		return err
+	}
+
+	return d.Graph(name).AcceptWithAnnotations(t)
+}
+
+// Add stores a new triple made of RDF terms to the default graph.
+func (d *Dataset) Add(t rdf.Triple) error {
+	return d.def.Add(t)
+}
+
+// AddQuad stores a new triple made of RDF terms to the graph of the
+// quad's graph name, an IRI or a blank node, or to the default graph
+// if the quad has none.
+func (d *Dataset) AddQuad(q rdf.Quad) error {
+	name, err := graphName(q.Graph)
+	if err != nil {
+		return err
+	}
+
+	return d.Graph(name).Add(q.Triple())
+}
+
+// Bytes returns the so far consumed triples of all the graphs. With the
+// N-Quads format set in the dataset's options they are written as lines
+// of N-Quads, the ones of the default graph first. With the TriG format
+// they are written as Turtle, the triples of the named graphs enclosed
+// in blocks labelled by the graph names. A blank node occurring in more
+// than one graph is never inlined. Otherwise only the default graph can
+// be written, the same way as Graph.Bytes does, and the triples of
+// a named graph result in ErrNamedGraphs.
+func (d *Dataset) Bytes() ([]byte, error) {
+	switch d.options.Format {
+	case FormatNQuads:
+		var b []byte
+		d.writeQuads(&b)
+		return b, nil
+	case FormatTriG:
+		return d.trig(), nil
+	}
+
+	if d.hasNamedGraphs() {
+		return nil, ErrNamedGraphs
+	}
+
+	return d.def.Bytes()
+}
+
+func (d *Dataset) writeQuads(b *[]byte) {
+	d.def.writeStatements(b, "")
+	for _, name := range d.Names() {
+		d.named[name].writeStatements(b, name)
+	}
+}
+
+func (d *Dataset) trig() []byte {
+	shared := d.sharedBlankNodes()
+	d.def.layout = d.def.arrange(shared)
+	for _, g := range d.named {
+		g.layout = g.arrange(shared)
+	}
+
+	// the graphs are written first to know what prefixes they use
+	var body []byte
+	var block string
+	d.writeBlocks(&body, &block)
+	closeBlock(&body, &block)
+
+	var b []byte
+	d.def.writePragmas(&b)
+
+	return append(b, body...)
+}
+
+// writeBlocks writes the triples of the default graph followed by the
+// blocks of the named graphs. The block is the name of the graph whose
+// block is open, its triples are written without opening another one.
+// The last block is left open.
+func (d *Dataset) writeBlocks(b *[]byte, block *string) {
+	if len(d.def.m) > 0 {
+		closeBlock(b, block)
+		d.def.writeSubjects(b)
+	}
+
+	for _, name := range d.Names() {
+		g := d.named[name]
+		if len(g.m) == 0 {
+			continue
+		}
+
+		if *block != name {
+			closeBlock(b, block)
+			*b = append(*b, []byte(fmt.Sprintf("%s {\n", d.def.sanitize(name, "iri", false)))...)
+			*block = name
+		}
+		g.writeSubjects(b)
+
+		// the pragmas of the default graph declare the prefixes of all of them
+		for prefix := range g.used {
+			d.def.used[prefix] = true
+		}
+	}
+}
+
+// closeBlock closes the open block of a named graph, if there is one.
+func closeBlock(b *[]byte, block *string) {
+	if *block == "" {
+		return
+	}
+
+	*b = append(*b, []byte("}\n")...)
+	*block = ""
+}
+
+// sharedBlankNodes returns the blank nodes occurring in more than one graph.
+func (d *Dataset) sharedBlankNodes() map[string]bool {
+	graphs := make(map[string]int)
+	count := func(g *Graph) {
+		nodes := make(map[string]bool)
+		for subject, predicates := range g.m {
+			if isBlankNode(subject) {
+				nodes[subject] = true
+			}
+			for _, objects := range predicates {
+				for _, obj := range objects {
+					if isBlankObject(obj) {
+						nodes[obj.item] = true
+					}
+				}
+			}
+		}
+		for node := range g.quotedBlankNodes() {
+			nodes[node] = true
+		}
+		for node := range nodes {
+			graphs[node]++
+		}
+	}
+
+	count(d.def)
+	for _, g := range d.named {
+		count(g)
+	}
+
+	shared := make(map[string]bool)
+	for node, n := range graphs {
+		if n > 1 {
+			shared[node] = true
+		}
+	}
+
+	return shared
+}
+
+// hasNamedGraphs reports whether any named graph holds a triple.
+func (d *Dataset) hasNamedGraphs() bool {
+	for _, g := range d.named {
+		if len(g.m) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// reset removes all the so far consumed triples from the dataset.
+func (d *Dataset) reset() {
+	d.def.reset()
+	d.named = make(map[string]*Graph)
+	d.names = nil
+}
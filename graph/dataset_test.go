@@ -0,0 +1,142 @@
+package graph_test
+
+import (
+	"testing"
+
+	"github.com/nvkp/turtle/assert"
+	"github.com/nvkp/turtle/graph"
+	"github.com/nvkp/turtle/rdf"
+)
+
+var datasetTestCases = map[string]struct {
+	options  graph.Options
+	quads    []rdf.Quad
+	expected string
+}{
+	"default_graph_first": {
+		quads: []rdf.Quad{
+			{Subject: rdf.IRI("http://example.org/a"), Predicate: rdf.IRI("http://example.org/b"), Object: rdf.Literal{Value: "c"}, Graph: rdf.IRI("http://example.org/g")},
+			{Subject: rdf.IRI("http://example.org/a"), Predicate: rdf.IRI("http://example.org/b"), Object: rdf.Literal{Value: "d"}},
+		},
+		expected: `<http://example.org/a> <http://example.org/b> "d" .
+<http://example.org/a> <http://example.org/b> "c" <http://example.org/g> .
+`,
+	},
+	"sorted_graphs": {
+		quads: []rdf.Quad{
+			{Subject: rdf.IRI("http://example.org/a"), Predicate: rdf.IRI("http://example.org/b"), Object: rdf.IRI("http://example.org/c"), Graph: rdf.IRI("http://example.org/h")},
+			{Subject: rdf.IRI("http://example.org/a"), Predicate: rdf.IRI("http://example.org/b"), Object: rdf.IRI("http://example.org/c"), Graph: rdf.BlankNode("g")},
+			{Subject: rdf.IRI("http://example.org/a"), Predicate: rdf.IRI("http://example.org/b"), Object: rdf.IRI("http://example.org/c"), Graph: rdf.IRI("http://example.org/g")},
+		},
+		expected: `<http://example.org/a> <http://example.org/b> <http://example.org/c> _:g .
+<http://example.org/a> <http://example.org/b> <http://example.org/c> <http://example.org/g> .
+<http://example.org/a> <http://example.org/b> <http://example.org/c> <http://example.org/h> .
+`,
+	},
+	"insertion_order": {
+		options: graph.Options{Order: graph.OrderInsertion},
+		quads: []rdf.Quad{
+			{Subject: rdf.IRI("http://example.org/a"), Predicate: rdf.IRI("http://example.org/b"), Object: rdf.Literal{Value: "c"}, Graph: rdf.IRI("http://example.org/h")},
+			{Subject: rdf.IRI("http://example.org/a"), Predicate: rdf.IRI("http://example.org/b"), Object: rdf.Literal{Value: "c"}, Graph: rdf.IRI("http://example.org/g")},
+		},
+		expected: `<http://example.org/a> <http://example.org/b> "c" <http://example.org/h> .
+<http://example.org/a> <http://example.org/b> "c" <http://example.org/g> .
+`,
+	},
+}
+
+func TestDataset(t *testing.T) {
+	for name, tc := range datasetTestCases {
+		t.Run(name, func(t *testing.T) {
+			tc.options.Format = graph.FormatNQuads
+			d := graph.NewDatasetWithOptions(tc.options)
+
+			for _, quad := range tc.quads {
+				err := d.AddQuad(quad)
+				assert.NoError(t, err, "no error was expected")
+			}
+
+			b, err := d.Bytes()
+			assert.NoError(t, err, "no error was expected")
+			assert.Equal(t, tc.expected, string(b), "dataset should have written the quads")
+		})
+	}
+}
+
+func TestDatasetGraph(t *testing.T) {
+	d := graph.NewDataset()
+
+	err := d.AcceptInGraph("http://example.org/g", [6]string{"http://example.org/a", "http://example.org/b", "c"})
+	assert.NoError(t, err, "no error was expected")
+	err = d.AcceptWithAnnotations([6]string{"http://example.org/a", "http://example.org/b", "d"})
+	assert.NoError(t, err, "no error was expected")
+
+	assert.Equal(t, []string{"http://example.org/g"}, d.Names(), "dataset should have returned the names of the named graphs")
+
+	b, err := d.Graph("http://example.org/g").Bytes()
+	assert.NoError(t, err, "no error was expected")
+	assert.Equal(t, "<http://example.org/a> <http://example.org/b> \"c\" .\n", string(b), "named graph should have held its triple")
+
+	b, err = d.Graph("").Bytes()
+	assert.NoError(t, err, "no error was expected")
+	assert.Equal(t, "<http://example.org/a> <http://example.org/b> \"d\" .\n", string(b), "default graph should have held its triple")
+}
+
+func TestDatasetError(t *testing.T) {
+	d := graph.NewDataset()
+
+	err := d.AddQuad(rdf.Quad{Subject: rdf.IRI("a"), Predicate: rdf.IRI("b"), Object: rdf.IRI("c"), Graph: rdf.Literal{Value: "g"}})
+	assert.ErrorIs(t, err, graph.ErrInvalidTerm, "literal graph name should have been rejected")
+
+	err = d.AddQuad(rdf.Quad{Subject: rdf.IRI("a"), Predicate: rdf.IRI("b"), Object: rdf.IRI("c"), Graph: rdf.IRI("g h")})
+	assert.ErrorIs(t, err, graph.ErrInvalidIRI, "graph name with a space should have been rejected")
+
+	err = d.AddQuad(rdf.Quad{Subject: rdf.IRI("a"), Predicate: rdf.IRI("b"), Object: rdf.IRI("c"), Graph: rdf.IRI("g")})
+	assert.NoError(t, err, "no error was expected")
+
+	_, err = d.Bytes()
+	assert.ErrorIs(t, err, graph.ErrNamedGraphs, "named graph cannot be written as Turtle")
+}
+
+func TestDatasetTriG(t *testing.T) {
+	d := graph.NewDatasetWithOptions(graph.Options{
+		Prefixes: map[string]string{
+			"ex":   "http://example.org/",
+			"foaf": "http://xmlns.com/foaf/0.1/",
+			"dc":   "http://purl.org/dc/elements/1.1/",
+		},
+		OmitUnusedPrefixes: true,
+		Format:             graph.FormatTriG,
+	})
+
+	triples := []struct {
+		graph  string
+		triple [6]string
+	}{
+		{"http://example.org/source2", [6]string{"http://example.org/bob", "http://xmlns.com/foaf/0.1/name", "Bob", "", "", "literal"}},
+		{"", [6]string{"http://example.org/source1", "http://purl.org/dc/elements/1.1/date", "2024-01-01", "", "", "literal"}},
+		{"http://example.org/source1", [6]string{"http://example.org/alice", "http://xmlns.com/foaf/0.1/knows", "_:b0", "", "", "iri"}},
+		{"http://example.org/source1", [6]string{"_:b0", "http://xmlns.com/foaf/0.1/name", "Carol", "", "", "literal"}},
+		{"http://example.org/source1", [6]string{"http://example.org/alice", "http://xmlns.com/foaf/0.1/knows", "_:b1", "", "", "iri"}},
+		{"http://example.org/source2", [6]string{"_:b1", "http://xmlns.com/foaf/0.1/name", "Dave", "", "", "literal"}},
+	}
+	for _, triple := range triples {
+		err := d.AcceptInGraph(triple.graph, triple.triple)
+		assert.NoError(t, err, "no error was expected")
+	}
+
+	b, err := d.Bytes()
+	assert.NoError(t, err, "no error was expected")
+	assert.Equal(t, `@prefix dc: <http://purl.org/dc/elements/1.1/> .
+@prefix ex: <http://example.org/> .
+@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+ex:source1 dc:date "2024-01-01" .
+ex:source1 {
+ex:alice foaf:knows [ foaf:name "Carol" ], _:b1 .
+}
+ex:source2 {
+_:b1 foaf:name "Dave" .
+ex:bob foaf:name "Bob" .
+}
+`, string(b), "dataset should have written the named graphs in blocks")
+}
@@ -2,9 +2,66 @@ package graph
 
 import (
 	"fmt"
+	"slices"
 	"sort"
+
+	"github.com/nvkp/turtle/rdf"
+)
+
+// Order determines in what order the subjects and their predicates
+// and objects are written.
+type Order int
+
+const (
+	// OrderSorted writes the triples sorted alphabetically first by subjects,
+	// then by predicates and then by objects.
+	OrderSorted Order = iota
+	// OrderInsertion writes the triples in the order they were consumed.
+	OrderInsertion
+)
+
+// QuoteStyle determines how the literals are quoted and escaped.
+type QuoteStyle int
+
+const (
+	// QuoteLong encloses the literals in quotation marks or apostrophes,
+	// whichever requires less escaping, and uses the long form with three
+	// of them for multiline literals so that their line breaks are kept.
+	QuoteLong QuoteStyle = iota
+	// QuoteDouble always encloses the literals in single quotation marks.
+	QuoteDouble
+	// QuoteNTriples encloses the literals in single quotation marks and
+	// escapes them the way canonical N-Triples does, including every
+	// control character.
+	QuoteNTriples
+)
+
+// Format determines the syntax of the written triples.
+type Format int
+
+const (
+	// FormatTurtle writes the triples as compact Turtle grouped by
+	// their subjects and predicates.
+	FormatTurtle Format = iota
+	// FormatNTriples writes every triple on its own line with the IRIs
+	// in full and the literals escaped the way canonical N-Triples does.
+	// The base and the prefixes are neither written nor applied.
+	FormatNTriples
+	// FormatNQuads writes the triples the same way as FormatNTriples,
+	// followed by the name of their graph unless it is the default one.
+	// Only a Dataset writes the named graphs.
+	FormatNQuads
+	// FormatTriG writes the triples the same way as FormatTurtle,
+	// the ones of every named graph enclosed in a block labelled
+	// by the name of the graph. Only a Dataset writes the named graphs.
+	FormatTriG
 )
 
+// statements reports whether the format writes every triple on its own line.
+func (f Format) statements() bool {
+	return f == FormatNTriples || f == FormatNQuads
+}
+
 // Options changes the behavior of the graph. It is passed to NewWithOptions.
 type Options struct {
 	// If set, will output a `@base` pragma at the start. Will normalize all URLs
@@ -12,8 +69,24 @@ type Options struct {
 	Base string
 	// If set, any encountering of the prefix URL prefixes will be normalized to
 	// use the prefix. Additionally, @prefix lines are output at the top of the
-	// document for each one.
+	// document for each one, sorted by the prefix names.
 	Prefixes map[string]string
+	// Same as Prefixes, but the @prefix lines are output in the given order
+	// and before the ones of Prefixes.
+	PrefixList PrefixList
+	// If set, only the prefixes used by the written triples
+	// are output as @prefix lines.
+	OmitUnusedPrefixes bool
+	// If set, the IRIs are written as they are, neither relativized
+	// against the base nor compacted to prefixed names.
+	Verbatim bool
+	// Order of the written triples. Sorted alphabetically by default.
+	Order Order
+	// Quotes used for the literals. The long form is used for
+	// multiline literals by default.
+	Quotes QuoteStyle
+	// Format of the written triples. Turtle by default.
+	Format Format
 }
 
 type object struct {
@@ -27,8 +100,13 @@ type object struct {
 // and can return a byte slice containing Turtle data of all
 // triples consumed.
 type Graph struct {
-	options Options
-	m       map[string]map[string][]object
+	options    Options
+	prefixes   PrefixList
+	used       map[string]bool
+	m          map[string]map[string][]object
+	subjects   []string
+	predicates map[string][]string
+	layout     *layout
 }
 
 // New returns a pointer to a new instance of graph.Graph. No options are set.
@@ -39,8 +117,11 @@ func New() *Graph {
 // NewWithOptions constructs a graph with options to tweak its behavior. See Options.
 func NewWithOptions(options Options) *Graph {
 	return &Graph{
-		options: options,
-		m:       make(map[string]map[string][]object),
+		options:    options,
+		prefixes:   options.prefixes(),
+		used:       make(map[string]bool),
+		m:          make(map[string]map[string][]object),
+		predicates: make(map[string][]string),
 	}
 }
 
@@ -60,6 +141,10 @@ func (g *Graph) accept(sub string, pred string, obj object) error {
 		return nil
 	}
 
+	if err := checkIRIs(sub, pred, obj); err != nil {
+		return err
+	}
+
 	predicates, ok := g.m[sub]
 	if !ok {
 		o := make([]object, 0, 1)
@@ -67,6 +152,8 @@ func (g *Graph) accept(sub string, pred string, obj object) error {
 		p := make(map[string][]object)
 		p[pred] = o
 		g.m[sub] = p
+		g.subjects = append(g.subjects, sub)
+		g.predicates[sub] = append(g.predicates[sub], pred)
 		return nil
 	}
 
@@ -75,6 +162,7 @@ func (g *Graph) accept(sub string, pred string, obj object) error {
 		o := make([]object, 0, 1)
 		o = append(o, obj)
 		g.m[sub][pred] = o
+		g.predicates[sub] = append(g.predicates[sub], pred)
 		return nil
 	}
 
@@ -85,6 +173,23 @@ func (g *Graph) accept(sub string, pred string, obj object) error {
 	return nil
 }
 
+// checkIRIs fails if the subject, the predicate or the object
+// written as an IRI contains a character not allowed in IRIs.
+func checkIRIs(sub string, pred string, obj object) error {
+	terms := []string{sub, pred}
+	if obj.typ == "iri" || obj.typ == "triple" || obj.typ == "" && rdf.IsQuotedTriple(obj.item) {
+		terms = append(terms, obj.item)
+	}
+
+	for _, term := range terms {
+		if err := checkIRI(term); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func contains(objects []object, s object) bool {
 	for _, object := range objects {
 		if object == s {
@@ -97,60 +202,88 @@ func contains(objects []object, s object) bool {
 
 // Bytes returns the so far consumed triples as a byte slice of
 // Turle data. The triples in the byte slice are sorted first
-// by subject, then by predicates, then by objects alphabetically.
+// by subject, then by predicates, then by objects alphabetically
+// unless the insertion order is set in the graph's options.
+//
+// A blank node referenced by a single object is written in its place
+// as a blank node property list `[ ... ]`, or as a collection `( ... )`
+// if it is the head of a well-formed RDF list. A blank node subject
+// that is not referenced at all is written as `[]`.
 func (g *Graph) Bytes() ([]byte, error) {
 	if g == nil || g.m == nil {
 		return nil, nil
 	}
 
-	var b []byte
+	if !g.options.Format.statements() {
+		g.layout = g.arrange(nil)
+	}
 
+	// the subjects are written first to know what prefixes they use
+	var body []byte
+	g.writeSubjects(&body)
+
+	var b []byte
 	g.writePragmas(&b)
 
-	subjects := g.sortSubjects()
-	for _, subject := range subjects {
-		b = append(b, []byte(fmt.Sprintf("%s ", g.sanitize(subject, "iri", false)))...)
-
-		predicates := sortPredicates(g.m[subject])
-
-		var predicateCounter int
-		for _, predicate := range predicates {
-			predicateCounter++
-			objects := g.m[subject][predicate]
-			sort.Slice(objects, func(i, j int) bool {
-				return objects[i].item < objects[j].item
-			})
-
-			// when single predicate for a subject
-			if len(predicates) == 1 {
-				// write the predicate
-				b = append(b, []byte(fmt.Sprintf("%s ", g.sanitize(predicate, "iri", true)))...)
-				// write the predicate's objects
-				g.writeObjects(&b, objects)
-				continue
-			}
+	return append(b, body...), nil
+}
 
-			// when multiple predicates for subject write predicate on a new line with indentation
-			b = append(b, []byte(fmt.Sprintf("\n\t%s ", g.sanitize(predicate, "iri", true)))...)
+func (g *Graph) writeSubjects(b *[]byte) {
+	if g.options.Format.statements() {
+		g.writeStatements(b, "")
+		return
+	}
 
-			// write the predicate's objects
-			g.writeObjects(&b, objects)
+	for _, subject := range g.orderSubjects() {
+		if g.layout != nil && g.layout.inlined[subject] {
+			continue
+		}
+		g.writeSubject(b, subject)
+	}
+}
 
-			// when predicate not last, write semicolon
-			if predicateCounter != len(predicates) {
-				b = append(b, []byte(" ;")...)
-				continue
-			}
+func (g *Graph) writeSubject(b *[]byte, subject string) {
+	if g.isAnonymous(subject) {
+		*b = append(*b, []byte("[] ")...)
+	} else {
+		*b = append(*b, []byte(fmt.Sprintf("%s ", g.sanitize(subject, "iri", false)))...)
+	}
+
+	predicates := g.orderPredicates(subject)
+
+	var predicateCounter int
+	for _, predicate := range predicates {
+		predicateCounter++
+		objects := g.orderObjects(subject, predicate)
+
+		// when single predicate for a subject
+		if len(predicates) == 1 {
+			// write the predicate
+			*b = append(*b, []byte(fmt.Sprintf("%s ", g.sanitize(predicate, "iri", true)))...)
+			// write the predicate's objects
+			g.writeObjects(b, objects)
+			continue
 		}
 
-		b = append(b, []byte(" .\n")...)
+		// when multiple predicates for subject write predicate on a new line with indentation
+		*b = append(*b, []byte(fmt.Sprintf("\n\t%s ", g.sanitize(predicate, "iri", true)))...)
+
+		// write the predicate's objects
+		g.writeObjects(b, objects)
+
+		// when predicate not last, write semicolon
+		if predicateCounter != len(predicates) {
+			*b = append(*b, []byte(" ;")...)
+			continue
+		}
 	}
-	return b, nil
+
+	*b = append(*b, []byte(" .\n")...)
 }
 
 func (g *Graph) writeObjects(b *[]byte, objects []object) {
 	for i, object := range objects {
-		*b = append(*b, []byte(g.sanitizeObject(object))...)
+		g.writeObject(b, object)
 		// when single object for predicate
 		if len(objects) == 1 {
 			break
@@ -164,14 +297,89 @@ func (g *Graph) writeObjects(b *[]byte, objects []object) {
 	}
 }
 
+// writeStatements writes every triple on its own line, followed
+// by the name of the graph unless it is empty.
+func (g *Graph) writeStatements(b *[]byte, name string) {
+	var graph rdf.Term
+	if name != "" {
+		graph = rdf.Resource(name)
+	}
+
+	for _, subject := range g.orderSubjects() {
+		for _, predicate := range g.orderPredicates(subject) {
+			for _, obj := range g.orderObjects(subject, predicate) {
+				t := statement(subject, predicate, obj)
+				q := rdf.Quad{Subject: t.Subject, Predicate: t.Predicate, Object: t.Object, Graph: graph}
+				*b = append(*b, []byte(q.String())...)
+				*b = append(*b, '\n')
+			}
+		}
+	}
+}
+
 func (g *Graph) writePragmas(b *[]byte) {
+	if g.options.Format.statements() {
+		return
+	}
+
 	if g.options.Base != "" {
 		*b = append(*b, []byte(fmt.Sprintf("@base <%s> .\n", g.options.Base))...)
 	}
 
-	for tag, url := range g.options.Prefixes {
-		*b = append(*b, []byte(fmt.Sprintf("@prefix %s: <%s> .\n", tag, url))...)
+	for _, p := range g.declaredPrefixes() {
+		*b = append(*b, []byte(fmt.Sprintf("@prefix %s: <%s> .\n", p.Name, p.IRI))...)
+	}
+}
+
+// declaredPrefixes returns the prefixes written as @prefix lines.
+func (g *Graph) declaredPrefixes() PrefixList {
+	if !g.options.OmitUnusedPrefixes {
+		return g.prefixes
+	}
+
+	declared := make(PrefixList, 0, len(g.used))
+	for _, p := range g.prefixes {
+		if g.used[p.Name] {
+			declared = append(declared, p)
+		}
+	}
+	return declared
+}
+
+// reset removes all the so far consumed triples from the graph.
+func (g *Graph) reset() {
+	g.m = make(map[string]map[string][]object)
+	g.subjects = nil
+	g.predicates = make(map[string][]string)
+}
+
+func (g *Graph) orderSubjects() []string {
+	if g.options.Order == OrderInsertion {
+		return g.subjects
 	}
+
+	return g.sortSubjects()
+}
+
+func (g *Graph) orderPredicates(subject string) []string {
+	if g.options.Order == OrderInsertion {
+		return g.predicates[subject]
+	}
+
+	return sortPredicates(g.m[subject])
+}
+
+func (g *Graph) orderObjects(subject string, predicate string) []object {
+	objects := g.m[subject][predicate]
+	if g.options.Order == OrderSorted {
+		// sort a copy so that the consumed order is kept
+		objects = slices.Clone(objects)
+		sort.Slice(objects, func(i, j int) bool {
+			return objects[i].item < objects[j].item
+		})
+	}
+
+	return objects
 }
 
 func (g *Graph) sortSubjects() []string {
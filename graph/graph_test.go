@@ -67,3 +67,108 @@ func TestGraph(t *testing.T) {
 		})
 	}
 }
+
+func TestGraphPrefixOrder(t *testing.T) {
+	prefixes := map[string]string{
+		"schema": "https://schema.org/",
+		"foaf":   "http://xmlns.com/foaf/0.1/",
+		"rdfs":   "http://www.w3.org/2000/01/rdf-schema#",
+		"dc":     "http://purl.org/dc/elements/1.1/",
+	}
+
+	// the map is ranged over in random order, so repeat to catch it
+	for i := 0; i < 10; i++ {
+		b, err := graph.NewWithOptions(graph.Options{Prefixes: prefixes}).Bytes()
+		assert.NoError(t, err, "no error was expected")
+		assert.Equal(t, `@prefix dc: <http://purl.org/dc/elements/1.1/> .
+@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+@prefix schema: <https://schema.org/> .
+`, string(b), "prefixes should have been sorted by their names")
+	}
+
+	b, err := graph.NewWithOptions(graph.Options{
+		Prefixes: prefixes,
+		PrefixList: graph.PrefixList{
+			{Name: "schema", IRI: "https://schema.org/"},
+			{Name: "ex", IRI: "http://example.org/"},
+			{Name: "foaf", IRI: "http://xmlns.com/foaf/0.1/"},
+		},
+	}).Bytes()
+	assert.NoError(t, err, "no error was expected")
+	assert.Equal(t, `@prefix schema: <https://schema.org/> .
+@prefix ex: <http://example.org/> .
+@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+@prefix dc: <http://purl.org/dc/elements/1.1/> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+`, string(b), "prefixes of the list should have kept their order")
+}
+
+func TestGraphNTriples(t *testing.T) {
+	g := graph.NewWithOptions(graph.Options{
+		Base:     "http://example.org/",
+		Prefixes: map[string]string{"ex": "http://example.org/"},
+		Format:   graph.FormatNTriples,
+	})
+
+	triples := [][6]string{
+		{"http://example.org/b", "a", "http://example.org/C", "", "", "iri"},
+		{"http://example.org/a", "http://example.org/knows", "_:b0", "", "", "iri"},
+		{"_:b0", "http://example.org/name", "Bob \"the\"\nbuilder", "en", "", "literal"},
+		{"http://example.org/a", "http://example.org/age", "42", "", "http://www.w3.org/2001/XMLSchema#integer", "literal"},
+	}
+	for _, triple := range triples {
+		err := g.AcceptWithAnnotations(triple)
+		assert.NoError(t, err, "no error was expected")
+	}
+
+	b, err := g.Bytes()
+	assert.NoError(t, err, "no error was expected")
+	assert.Equal(t, `_:b0 <http://example.org/name> "Bob \"the\"\nbuilder"@en .
+<http://example.org/a> <http://example.org/age> "42"^^<http://www.w3.org/2001/XMLSchema#integer> .
+<http://example.org/a> <http://example.org/knows> _:b0 .
+<http://example.org/b> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://example.org/C> .
+`, string(b), "graph should have written a triple per line")
+}
+
+func TestGraphQuotedTriple(t *testing.T) {
+	triples := [][6]string{
+		{"<< <http://example.org/a> <http://example.org/b> <http://example.org/c> >>", "http://example.org/d", "e", "", "", "literal"},
+		{"http://example.org/f", "http://example.org/g", "<< <http://example.org/a> <http://example.org/b> \"1\"^^<http://www.w3.org/2001/XMLSchema#integer> >>", "", "", "triple"},
+	}
+
+	for name, tc := range map[string]struct {
+		format   graph.Format
+		expected string
+	}{
+		"turtle": {
+			format: graph.FormatTurtle,
+			expected: `@prefix ex: <http://example.org/> .
+<< ex:a ex:b ex:c >> ex:d "e" .
+ex:f ex:g << ex:a ex:b 1 >> .
+`,
+		},
+		"ntriples": {
+			format: graph.FormatNTriples,
+			expected: `<< <http://example.org/a> <http://example.org/b> <http://example.org/c> >> <http://example.org/d> "e" .
+<http://example.org/f> <http://example.org/g> << <http://example.org/a> <http://example.org/b> "1"^^<http://www.w3.org/2001/XMLSchema#integer> >> .
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			g := graph.NewWithOptions(graph.Options{
+				Prefixes: map[string]string{"ex": "http://example.org/"},
+				Format:   tc.format,
+			})
+
+			for _, triple := range triples {
+				err := g.AcceptWithAnnotations(triple)
+				assert.NoError(t, err, "no error was expected")
+			}
+
+			b, err := g.Bytes()
+			assert.NoError(t, err, "no error was expected")
+			assert.Equal(t, tc.expected, string(b), "graph should have written the quoted triples")
+		})
+	}
+}
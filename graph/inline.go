@@ -0,0 +1,202 @@
+package graph
+
+import "github.com/nvkp/turtle/rdf"
+
+const (
+	rdfFirstIRI = "http://www.w3.org/1999/02/22-rdf-syntax-ns#first"
+	rdfRestIRI  = "http://www.w3.org/1999/02/22-rdf-syntax-ns#rest"
+	rdfNilIRI   = "http://www.w3.org/1999/02/22-rdf-syntax-ns#nil"
+)
+
+// layout describes which blank nodes are written in place of their
+// only reference instead of as subjects of their own.
+type layout struct {
+	// references counts the objects referencing every blank node.
+	references map[string]int
+	// inlined contains the blank nodes written as the object
+	// referencing them, either as a collection or as a blank
+	// node property list.
+	inlined map[string]bool
+	// lists contains the items of the inlined blank nodes
+	// that are heads of well-formed collections.
+	lists map[string][]object
+	// shared contains the blank nodes occurring in the quoted
+	// triples or in the other graphs of a dataset as well,
+	// which keep their labels.
+	shared map[string]bool
+}
+
+// arrange returns the layout of the so far consumed triples. A blank node
+// referenced exactly once is inlined unless the references form a cycle
+// in which case the first blank node of the cycle is written as a subject,
+// or unless it is shared with a quoted triple or the other graphs of a dataset.
+func (g *Graph) arrange(shared map[string]bool) *layout {
+	l := &layout{
+		references: make(map[string]int),
+		inlined:    make(map[string]bool),
+		lists:      make(map[string][]object),
+		shared:     g.quotedBlankNodes(),
+	}
+	for node := range shared {
+		l.shared[node] = true
+	}
+
+	referrers := make(map[string]string)
+	for subject, predicates := range g.m {
+		for _, objects := range predicates {
+			for _, obj := range objects {
+				if isBlankObject(obj) {
+					l.references[obj.item]++
+					referrers[obj.item] = subject
+				}
+			}
+		}
+	}
+
+	for node, n := range l.references {
+		if n == 1 && !l.shared[node] {
+			l.inlined[node] = true
+		}
+	}
+
+	// every inlined blank node has to be reachable from a written subject
+	for _, subject := range g.orderSubjects() {
+		if !l.inlined[subject] {
+			continue
+		}
+
+		seen := make(map[string]bool)
+		for r := referrers[subject]; l.inlined[r] && !seen[r]; r = referrers[r] {
+			if r == subject {
+				delete(l.inlined, subject)
+				break
+			}
+			seen[r] = true
+		}
+	}
+
+	for node := range l.inlined {
+		if items, ok := g.collection(node, l.inlined); ok {
+			l.lists[node] = items
+		}
+	}
+
+	return l
+}
+
+// collection returns the items of the collection starting with the blank
+// node. It fails unless every node of the collection is inlined and has
+// exactly one rdf:first and one rdf:rest predicate with a single object
+// and the last of them is followed by rdf:nil.
+func (g *Graph) collection(node string, inlined map[string]bool) ([]object, bool) {
+	var items []object
+	for node != rdfNilIRI {
+		predicates := g.m[node]
+		if !inlined[node] || len(predicates) != 2 {
+			return nil, false
+		}
+
+		first, rest := predicates[rdfFirstIRI], predicates[rdfRestIRI]
+		if len(first) != 1 || len(rest) != 1 || rest[0].typ == "literal" {
+			return nil, false
+		}
+
+		items = append(items, first[0])
+		node = rest[0].item
+	}
+
+	return items, true
+}
+
+// writeObject writes the object, the inlined blank nodes
+// as a collection or as a blank node property list.
+func (g *Graph) writeObject(b *[]byte, obj object) {
+	if g.layout == nil {
+		*b = append(*b, []byte(g.sanitizeObject(obj))...)
+		return
+	}
+
+	if items, ok := g.layout.lists[obj.item]; ok && isBlankObject(obj) {
+		g.writeCollection(b, items)
+		return
+	}
+
+	if g.layout.inlined[obj.item] && isBlankObject(obj) {
+		g.writePropertyList(b, obj.item)
+		return
+	}
+
+	if obj.item == rdfNilIRI && obj.typ != "literal" {
+		*b = append(*b, []byte("()")...)
+		return
+	}
+
+	*b = append(*b, []byte(g.sanitizeObject(obj))...)
+}
+
+func (g *Graph) writeCollection(b *[]byte, items []object) {
+	*b = append(*b, '(')
+	for _, item := range items {
+		*b = append(*b, ' ')
+		g.writeObject(b, item)
+	}
+	*b = append(*b, []byte(" )")...)
+}
+
+func (g *Graph) writePropertyList(b *[]byte, node string) {
+	predicates := g.orderPredicates(node)
+	if len(predicates) == 0 {
+		*b = append(*b, []byte("[]")...)
+		return
+	}
+
+	*b = append(*b, '[')
+	for i, predicate := range predicates {
+		if i > 0 {
+			*b = append(*b, []byte(" ;")...)
+		}
+		*b = append(*b, []byte(" "+g.sanitize(predicate, "iri", true)+" ")...)
+		g.writeObjects(b, g.orderObjects(node, predicate))
+	}
+	*b = append(*b, []byte(" ]")...)
+}
+
+// isAnonymous reports whether the subject is a blank node
+// not referenced by any object that can be written as [].
+func (g *Graph) isAnonymous(subject string) bool {
+	return g.layout != nil && isBlankNode(subject) && g.layout.references[subject] == 0 && !g.layout.shared[subject]
+}
+
+func isBlankObject(obj object) bool {
+	return obj.typ != "literal" && isBlankNode(obj.item)
+}
+
+// quotedBlankNodes returns the blank nodes occurring in the quoted
+// triples of the graph, the ones of the nested quoted triples included.
+func (g *Graph) quotedBlankNodes() map[string]bool {
+	nodes := make(map[string]bool)
+	for subject, predicates := range g.m {
+		if q, ok := quotedTriple(subject); ok {
+			addBlankNodes(nodes, q)
+		}
+		for _, objects := range predicates {
+			for _, obj := range objects {
+				if q, ok := quotedTriple(obj.item); ok && obj.typ != "literal" {
+					addBlankNodes(nodes, q)
+				}
+			}
+		}
+	}
+
+	return nodes
+}
+
+func addBlankNodes(nodes map[string]bool, t rdf.Term) {
+	switch t := t.(type) {
+	case rdf.BlankNode:
+		nodes[t.String()] = true
+	case rdf.QuotedTriple:
+		addBlankNodes(nodes, t.Subject)
+		addBlankNodes(nodes, t.Object)
+	}
+}
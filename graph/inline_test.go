@@ -0,0 +1,145 @@
+package graph_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/nvkp/turtle/assert"
+	"github.com/nvkp/turtle/graph"
+)
+
+const (
+	rdfFirst = "http://www.w3.org/1999/02/22-rdf-syntax-ns#first"
+	rdfRest  = "http://www.w3.org/1999/02/22-rdf-syntax-ns#rest"
+	rdfNil   = "http://www.w3.org/1999/02/22-rdf-syntax-ns#nil"
+)
+
+var inlineTestCases = map[string]struct {
+	triples  [][6]string
+	expected string
+}{
+	"property_list": {
+		triples: [][6]string{
+			{"http://example.org/a", "http://example.org/b", "_:b0", "", "", "iri"},
+			{"_:b0", "http://example.org/c", "d", "", "", "literal"},
+			{"_:b0", "http://example.org/e", "f", "", "", "literal"},
+		},
+		expected: `ex:a ex:b [ ex:c "d" ; ex:e "f" ] .
+`,
+	},
+	"nested_property_lists": {
+		triples: [][6]string{
+			{"http://example.org/a", "http://example.org/b", "_:b0", "", "", "iri"},
+			{"_:b0", "http://example.org/c", "_:b1", "", "", "iri"},
+			{"_:b1", "http://example.org/d", "e", "", "", "literal"},
+			{"_:b1", "http://example.org/d", "f", "", "", "literal"},
+		},
+		expected: `ex:a ex:b [ ex:c [ ex:d "e", "f" ] ] .
+`,
+	},
+	"collection": {
+		triples: [][6]string{
+			{"http://example.org/a", "http://example.org/b", "_:b0", "", "", "iri"},
+			{"_:b0", rdfFirst, "1", "", "", "literal"},
+			{"_:b0", rdfRest, "_:b1", "", "", "iri"},
+			{"_:b1", rdfFirst, "_:b2", "", "", "iri"},
+			{"_:b1", rdfRest, rdfNil, "", "", "iri"},
+			{"_:b2", "http://example.org/c", "http://example.org/d", "", "", "iri"},
+		},
+		expected: `ex:a ex:b ( "1" [ ex:c ex:d ] ) .
+`,
+	},
+	"empty_collection": {
+		triples: [][6]string{
+			{"http://example.org/a", "http://example.org/b", rdfNil, "", "", "iri"},
+		},
+		expected: `ex:a ex:b () .
+`,
+	},
+	"malformed_collection": {
+		triples: [][6]string{
+			{"http://example.org/a", "http://example.org/b", "_:b0", "", "", "iri"},
+			{"_:b0", rdfFirst, "1", "", "", "literal"},
+			{"_:b0", rdfFirst, "2", "", "", "literal"},
+			{"_:b0", rdfRest, rdfNil, "", "", "iri"},
+		},
+		expected: `ex:a ex:b [ <http://www.w3.org/1999/02/22-rdf-syntax-ns#first> "1", "2" ; <http://www.w3.org/1999/02/22-rdf-syntax-ns#rest> () ] .
+`,
+	},
+	"shared_blank_node": {
+		triples: [][6]string{
+			{"http://example.org/a", "http://example.org/b", "_:b0", "", "", "iri"},
+			{"http://example.org/c", "http://example.org/b", "_:b0", "", "", "iri"},
+			{"_:b0", "http://example.org/d", "e", "", "", "literal"},
+		},
+		expected: `_:b0 ex:d "e" .
+ex:a ex:b _:b0 .
+ex:c ex:b _:b0 .
+`,
+	},
+	"quoted_blank_node": {
+		triples: [][6]string{
+			{"http://example.org/a", "http://example.org/b", "_:b0", "", "", "iri"},
+			{"_:b0", "http://example.org/c", "d", "", "", "literal"},
+			{"<< _:b0 <http://example.org/c> \"d\" >>", "http://example.org/e", "f", "", "", "literal"},
+		},
+		expected: `<< _:b0 ex:c "d" >> ex:e "f" .
+_:b0 ex:c "d" .
+ex:a ex:b _:b0 .
+`,
+	},
+	"cycle": {
+		triples: [][6]string{
+			{"_:b0", "http://example.org/b", "_:b1", "", "", "iri"},
+			{"_:b1", "http://example.org/b", "_:b0", "", "", "iri"},
+		},
+		expected: `_:b0 ex:b [ ex:b _:b0 ] .
+`,
+	},
+	"anonymous_subject": {
+		triples: [][6]string{
+			{"_:b0", "http://example.org/b", "c", "", "", "literal"},
+			{"_:b0", "http://example.org/d", "_:b1", "", "", "iri"},
+		},
+		expected: `[] 
+	ex:b "c" ;
+	ex:d [] .
+`,
+	},
+}
+
+func TestInline(t *testing.T) {
+	for name, tc := range inlineTestCases {
+		t.Run(name, func(t *testing.T) {
+			g := graph.NewWithOptions(graph.Options{
+				Prefixes:           map[string]string{"ex": "http://example.org/"},
+				OmitUnusedPrefixes: true,
+			})
+
+			for _, triple := range tc.triples {
+				err := g.AcceptWithAnnotations(triple)
+				assert.NoError(t, err, "no error was expected")
+			}
+
+			b, err := g.Bytes()
+			assert.NoError(t, err, "no error was expected")
+			assert.Equal(t, "@prefix ex: <http://example.org/> .\n"+tc.expected, string(b), "blank nodes should have been inlined")
+		})
+	}
+}
+
+func TestWriterBlankNodes(t *testing.T) {
+	var buf bytes.Buffer
+	w := graph.NewWriter(&buf, graph.Options{})
+
+	err := w.AcceptWithAnnotations([6]string{"http://example.org/a", "http://example.org/b", "_:b0", "", "", "iri"})
+	assert.NoError(t, err, "no error was expected")
+	err = w.AcceptWithAnnotations([6]string{"_:b0", "http://example.org/c", "d", "", "", "literal"})
+	assert.NoError(t, err, "no error was expected")
+	err = w.Flush()
+	assert.NoError(t, err, "no error was expected")
+
+	assert.Equal(t, `_:b0 <http://example.org/c> "d" .
+<http://example.org/a> <http://example.org/b> _:b0 .
+`, buf.String(), "writer should have kept the blank node labels")
+}
@@ -0,0 +1,84 @@
+package graph
+
+import (
+	"iter"
+
+	"github.com/nvkp/turtle/rdf"
+)
+
+// All returns an iterator over the so far consumed triples made of RDF
+// terms in the same order as Bytes writes them. The graph must not be
+// changed during the iteration.
+func (g *Graph) All() iter.Seq[rdf.Triple] {
+	return func(yield func(rdf.Triple) bool) {
+		if g == nil {
+			return
+		}
+
+		for _, subject := range g.orderSubjects() {
+			for _, predicate := range g.orderPredicates(subject) {
+				for _, obj := range g.orderObjects(subject, predicate) {
+					if !yield(statement(subject, predicate, obj)) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+// Subjects returns an iterator over the distinct subjects
+// of the so far consumed triples.
+func (g *Graph) Subjects() iter.Seq[rdf.Term] {
+	return func(yield func(rdf.Term) bool) {
+		if g == nil {
+			return
+		}
+
+		for _, subject := range g.orderSubjects() {
+			if !yield(rdf.Resource(subject)) {
+				return
+			}
+		}
+	}
+}
+
+// Predicates returns an iterator over the distinct predicates
+// of the subject's triples.
+func (g *Graph) Predicates(s rdf.Term) iter.Seq[rdf.IRI] {
+	return func(yield func(rdf.IRI) bool) {
+		subject, ok := subjectKey(s)
+		if g == nil || !ok {
+			return
+		}
+
+		for _, predicate := range g.orderPredicates(subject) {
+			if !yield(predicateIRI(predicate)) {
+				return
+			}
+		}
+	}
+}
+
+// Objects returns an iterator over the objects of the triples
+// of the subject and the predicate.
+func (g *Graph) Objects(s rdf.Term, p rdf.IRI) iter.Seq[rdf.Term] {
+	return func(yield func(rdf.Term) bool) {
+		subject, ok := subjectKey(s)
+		if g == nil || !ok {
+			return
+		}
+
+		for _, predicate := range g.orderPredicates(subject) {
+			if predicateIRI(predicate) != p {
+				continue
+			}
+
+			for _, obj := range g.orderObjects(subject, predicate) {
+				if !yield(objectTerm(obj)) {
+					return
+				}
+			}
+		}
+	}
+}
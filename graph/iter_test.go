@@ -0,0 +1,76 @@
+package graph_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/nvkp/turtle/assert"
+	"github.com/nvkp/turtle/graph"
+	"github.com/nvkp/turtle/rdf"
+)
+
+func newIterGraph(t *testing.T) *graph.Graph {
+	g := graph.New()
+
+	triples := [][6]string{
+		{"http://example.org/bob", "http://xmlns.com/foaf/0.1/name", "Bob", "", "", "literal"},
+		{"http://example.org/alice", "a", "http://xmlns.com/foaf/0.1/Person", "", "", "iri"},
+		{"http://example.org/alice", "http://xmlns.com/foaf/0.1/knows", "http://example.org/bob", "", "", "iri"},
+		{"http://example.org/alice", "http://xmlns.com/foaf/0.1/knows", "_:b0", "", "", "iri"},
+		{"_:b0", "http://xmlns.com/foaf/0.1/name", "Carol", "en", "", "literal"},
+	}
+	for _, triple := range triples {
+		err := g.AcceptWithAnnotations(triple)
+		assert.NoError(t, err, "no error was expected")
+	}
+
+	return g
+}
+
+func TestGraphAll(t *testing.T) {
+	g := newIterGraph(t)
+
+	expected := []rdf.Triple{
+		{Subject: rdf.BlankNode("b0"), Predicate: rdf.IRI("http://xmlns.com/foaf/0.1/name"), Object: rdf.Literal{Value: "Carol", Lang: "en"}},
+		{Subject: rdf.IRI("http://example.org/alice"), Predicate: rdf.IRI("http://www.w3.org/1999/02/22-rdf-syntax-ns#type"), Object: rdf.IRI("http://xmlns.com/foaf/0.1/Person")},
+		{Subject: rdf.IRI("http://example.org/alice"), Predicate: rdf.IRI("http://xmlns.com/foaf/0.1/knows"), Object: rdf.BlankNode("b0")},
+		{Subject: rdf.IRI("http://example.org/alice"), Predicate: rdf.IRI("http://xmlns.com/foaf/0.1/knows"), Object: rdf.IRI("http://example.org/bob")},
+		{Subject: rdf.IRI("http://example.org/bob"), Predicate: rdf.IRI("http://xmlns.com/foaf/0.1/name"), Object: rdf.Literal{Value: "Bob"}},
+	}
+	assert.Equal(t, expected, slices.Collect(g.All()), "graph should have yielded all the triples in order")
+
+	var first []rdf.Triple
+	for triple := range g.All() {
+		first = append(first, triple)
+		break
+	}
+	assert.Equal(t, expected[:1], first, "graph should have stopped yielding the triples")
+}
+
+func TestGraphSubjects(t *testing.T) {
+	g := newIterGraph(t)
+
+	expected := []rdf.Term{rdf.BlankNode("b0"), rdf.IRI("http://example.org/alice"), rdf.IRI("http://example.org/bob")}
+	assert.Equal(t, expected, slices.Collect(g.Subjects()), "graph should have yielded the distinct subjects")
+}
+
+func TestGraphPredicates(t *testing.T) {
+	g := newIterGraph(t)
+
+	expected := []rdf.IRI{"http://www.w3.org/1999/02/22-rdf-syntax-ns#type", "http://xmlns.com/foaf/0.1/knows"}
+	assert.Equal(t, expected, slices.Collect(g.Predicates(rdf.IRI("http://example.org/alice"))), "graph should have yielded the predicates of the subject")
+	assert.Equal(t, []rdf.IRI(nil), slices.Collect(g.Predicates(rdf.Literal{Value: "alice"})), "graph should have yielded no predicates of a literal")
+}
+
+func TestGraphObjects(t *testing.T) {
+	g := newIterGraph(t)
+
+	expected := []rdf.Term{rdf.BlankNode("b0"), rdf.IRI("http://example.org/bob")}
+	assert.Equal(t, expected, slices.Collect(g.Objects(rdf.IRI("http://example.org/alice"), "http://xmlns.com/foaf/0.1/knows")), "graph should have yielded the objects of the subject and predicate")
+
+	expected = []rdf.Term{rdf.IRI("http://xmlns.com/foaf/0.1/Person")}
+	assert.Equal(t, expected, slices.Collect(g.Objects(rdf.IRI("http://example.org/alice"), "http://www.w3.org/1999/02/22-rdf-syntax-ns#type")), "graph should have yielded the objects of the keyword a")
+
+	expected = []rdf.Term{rdf.Literal{Value: "Carol", Lang: "en"}}
+	assert.Equal(t, expected, slices.Collect(g.Objects(rdf.BlankNode("b0"), "http://xmlns.com/foaf/0.1/name")), "graph should have yielded the objects of the blank node")
+}
@@ -0,0 +1,133 @@
+package graph
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/nvkp/turtle/rdf"
+)
+
+// shorthands maps the datatypes Turtle has a shorthand for to the canonical
+// lexical forms of the datatype that can be written without the quotes.
+var shorthands = map[string]*regexp.Regexp{
+	rdf.XSDInteger: regexp.MustCompile(`^(?:0|-?[1-9][0-9]*)$`),
+	rdf.XSDDecimal: regexp.MustCompile(`^-?(?:0|[1-9][0-9]*)\.(?:0|[0-9]*[1-9])$`),
+	rdf.XSDDouble:  regexp.MustCompile(`^-?(?:[1-9]\.(?:0|[0-9]*[1-9])E-?(?:0|[1-9][0-9]*)|0\.0E0)$`),
+	rdf.XSDBoolean: regexp.MustCompile(`^(?:true|false)$`),
+}
+
+// isShorthand reports whether the literal is in the canonical
+// form of a datatype that can be written without the quotes.
+func isShorthand(str string, datatype string) bool {
+	regex, ok := shorthands[datatype]
+	return ok && regex.MatchString(str)
+}
+
+// quoteLiteral encloses the literal in quotes of the given style
+// and escapes the characters that cannot be part of it as they are.
+func quoteLiteral(str string, style QuoteStyle) string {
+	var edge string
+	switch style {
+	case QuoteDouble:
+		edge = `"`
+	case QuoteNTriples:
+		return rdf.Literal{Value: str}.String()
+	default:
+		edge = literalEdge(str)
+	}
+
+	return fmt.Sprintf("%s%s%s", edge, escapeLiteral(str, edge), edge)
+}
+
+// literalEdge returns the quotes requiring the least escaping of the
+// literal, three of them if the literal spans multiple lines.
+func literalEdge(str string) string {
+	if !strings.ContainsRune(str, runeNewLine) {
+		if strings.Count(str, `"`) > strings.Count(str, `'`) {
+			return `'`
+		}
+
+		return `"`
+	}
+
+	quotations, apostrophes := longEscapes(str, runeQuotation), longEscapes(str, runeApostrophe)
+	if quotations < apostrophes || quotations == apostrophes && strings.ContainsRune(str, runeApostrophe) {
+		return `"""`
+	}
+
+	return `'''`
+}
+
+// escapeLiteral escapes backslashes, the quotes that would end the literal
+// and control characters other than tabulators so that the literal is read
+// back unchanged. Long literals keep their line breaks and the quotes
+// that do not form their edge.
+func escapeLiteral(str string, edge string) string {
+	long := len(edge) > 1
+	quote := rune(edge[0])
+
+	var b strings.Builder
+	b.Grow(len(str))
+
+	var quotes int
+	for i, r := range str {
+		if r != quote {
+			quotes = 0
+		}
+
+		switch e, ok := rdf.EscapeChar(r); {
+		case r == quote && long:
+			if escapeLongQuote(quotes, i+1 == len(str)) {
+				b.WriteRune(runeBackslash)
+				quotes = 0
+			} else {
+				quotes++
+			}
+			b.WriteRune(r)
+		case r == quote:
+			b.WriteRune(runeBackslash)
+			b.WriteRune(r)
+		case r == runeNewLine && long, r == '\t', r == runeQuotation, r == runeApostrophe:
+			b.WriteRune(r)
+		case ok:
+			b.WriteRune(runeBackslash)
+			b.WriteByte(e)
+		case unicode.IsControl(r):
+			fmt.Fprintf(&b, `\u%04X`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// longEscapes counts the quotes that need to be escaped
+// in a long literal enclosed in three of them.
+func longEscapes(str string, quote rune) int {
+	var n, quotes int
+	for i, r := range str {
+		if r != quote {
+			quotes = 0
+			continue
+		}
+
+		if escapeLongQuote(quotes, i+1 == len(str)) {
+			n++
+			quotes = 0
+			continue
+		}
+		quotes++
+	}
+
+	return n
+}
+
+// escapeLongQuote reports whether a quote in a long literal has to be
+// escaped. Up to two quotes can follow each other unless they end the
+// literal where they would merge with its edge.
+func escapeLongQuote(preceding int, last bool) bool {
+	return preceding == 2 || last
+}
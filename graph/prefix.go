@@ -0,0 +1,58 @@
+package graph
+
+import (
+	"slices"
+	"sort"
+)
+
+// Prefix is a single prefix name with the IRI it stands for.
+type Prefix struct {
+	Name string
+	IRI  string
+}
+
+// PrefixList is an ordered list of prefixes.
+type PrefixList []Prefix
+
+// Map returns the prefixes of the list as a map of their names to their IRIs.
+func (l PrefixList) Map() map[string]string {
+	m := make(map[string]string, len(l))
+	for _, p := range l {
+		if _, ok := m[p.Name]; !ok {
+			m[p.Name] = p.IRI
+		}
+	}
+	return m
+}
+
+// prefixes returns all the prefixes of the options, first the ones of
+// the prefix list in the given order, then the remaining ones of the map
+// sorted by their names.
+func (o Options) prefixes() PrefixList {
+	l := make(PrefixList, 0, len(o.PrefixList)+len(o.Prefixes))
+	for _, p := range o.PrefixList {
+		if !l.contains(p.Name) {
+			l = append(l, p)
+		}
+	}
+
+	names := make([]string, 0, len(o.Prefixes))
+	for name := range o.Prefixes {
+		if !l.contains(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		l = append(l, Prefix{Name: name, IRI: o.Prefixes[name]})
+	}
+
+	return l
+}
+
+func (l PrefixList) contains(name string) bool {
+	return slices.ContainsFunc(l, func(p Prefix) bool {
+		return p.Name == name
+	})
+}
@@ -1,20 +1,33 @@
 package graph
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
 	"strings"
 	"unicode"
+
+	"github.com/nvkp/turtle/rdf"
 )
 
+// ErrInvalidIRI is returned when an IRI of the consumed triple contains
+// a character that cannot be written in an IRI reference.
+var ErrInvalidIRI = errors.New("invalid IRI")
+
 const (
 	rdfTypeIRI     = "http://www.w3.org/1999/02/22-rdf-syntax-ns#type"
 	runeNewLine    = '\u000A' // \n
 	runeApostrophe = '\u0027' // '
 	runeQuotation  = '\u0022' // "
+	runeBackslash  = '\u005C' // \
 )
 
 func (g *Graph) sanitizeObject(obj object) string {
+	// N-Triples has no shorthand forms of the literals
+	if obj.typ != "iri" && obj.label == "" && g.options.Quotes != QuoteNTriples && isShorthand(obj.item, obj.datatype) {
+		return obj.item
+	}
+
 	item := g.sanitize(obj.item, obj.typ, false)
 
 	if obj.label != "" {
@@ -22,22 +35,36 @@ func (g *Graph) sanitizeObject(obj object) string {
 	}
 
 	if obj.datatype != "" {
-		return fmt.Sprintf("%s^^%s", item, obj.datatype)
+		return fmt.Sprintf("%s^^%s", item, g.sanitizeDatatype(obj.datatype))
 	}
 
 	return item
 }
 
+// sanitizeDatatype writes an absolute datatype IRI the same way as the
+// other IRIs. Any other datatype is kept as it was given.
+func (g *Graph) sanitizeDatatype(datatype string) string {
+	if isIRI(datatype) {
+		return g.sanitize(datatype, "iri", false)
+	}
+
+	return datatype
+}
+
 func (g *Graph) sanitize(str string, typ string, predicate bool) string {
 	if len(str) == 0 {
 		return str
 	}
 
+	if q, ok := quotedTriple(str); ok && typ != "literal" {
+		return g.sanitizeQuoted(q)
+	}
+
 	if isBlankNode(str) {
 		return str
 	}
 
-	if typ == "iri" || (typ == "" && isIRI(str)) {
+	if typ == "iri" || (typ == "" && (isIRI(str) || g.isPrefixedName(str))) {
 		if str == "." && g.options.Base != "" {
 			return g.options.Base
 		}
@@ -46,25 +73,128 @@ func (g *Graph) sanitize(str string, typ string, predicate bool) string {
 			return fmt.Sprintf("<%s>", rdfTypeIRI)
 		}
 
-		for key := range g.options.Prefixes {
-			if strings.HasPrefix(str, key+":") {
-				return str
+		if !g.options.Verbatim {
+			if compacted, ok := g.compact(str); ok {
+				return compacted
 			}
 		}
 
-		if g.options.Base != "" && strings.HasPrefix(str, g.options.Base) {
-			if g.options.Base == str {
-				str = "."
+		if g.isPrefixedName(str) {
+			return str
+		}
+
+		if !g.options.Verbatim && g.options.Base != "" {
+			if relative, ok := relativize(g.options.Base, str); ok {
+				return rdf.IRI(relative).String()
 			}
+		}
 
-			return fmt.Sprintf("<%s>", strings.TrimPrefix(str, g.options.Base))
+		return rdf.IRI(str).String()
+	}
+
+	return quoteLiteral(str, g.options.Quotes)
+}
+
+// sanitizeQuoted writes the quoted triple with its terms
+// written the same way as the ones of the other triples.
+func (g *Graph) sanitizeQuoted(q rdf.QuotedTriple) string {
+	terms := make([]string, 0, 3)
+	for i, term := range []rdf.Term{q.Subject, q.Predicate, q.Object} {
+		switch t := term.(type) {
+		case rdf.IRI:
+			terms = append(terms, g.sanitize(string(t), "iri", i == 1))
+		case rdf.Literal:
+			terms = append(terms, g.sanitizeObject(object{item: t.Value, label: rdf.JoinLanguageTag(t.Lang, t.Direction), datatype: string(t.Datatype), typ: "literal"}))
+		case rdf.QuotedTriple:
+			terms = append(terms, g.sanitizeQuoted(t))
+		default:
+			terms = append(terms, term.String())
 		}
+	}
+
+	return "<< " + strings.Join(terms, " ") + " >>"
+}
+
+// isPrefixedName reports whether the string is a prefixed name
+// of one of the prefixes and marks the prefix as used if so.
+func (g *Graph) isPrefixedName(str string) bool {
+	for _, p := range g.prefixes {
+		if strings.HasPrefix(str, p.Name+":") {
+			g.used[p.Name] = true
+			return true
+		}
+	}
+
+	return false
+}
+
+// relativize returns the IRI relative to the base. It fails unless
+// the relative IRI is sure to resolve back against the base to the
+// same IRI, e.g. the base does not end with a slash for a path.
+func relativize(base string, iri string) (string, bool) {
+	relative, ok := strings.CutPrefix(iri, base)
+	if !ok || strings.Contains(base, "#") {
+		return "", false
+	}
+
+	switch {
+	case relative == "":
+		return relative, true
+	case relative[0] == '#':
+		return relative, true
+	case strings.Contains(base, "?"):
+		return "", false
+	case relative[0] == '?':
+		return relative, true
+	case relative[0] == '/':
+		// only a base without a path is followed by an absolute path
+		_, authority, _ := strings.Cut(base, "://")
+		return relative, !strings.HasPrefix(relative, "//") && authority != "" && !strings.Contains(authority, "/")
+	}
+
+	// the first segment cannot be mistaken for a scheme or a dot segment
+	segment, _, _ := strings.Cut(relative, "/")
+	segment, _, _ = strings.Cut(segment, "?")
+	segment, _, _ = strings.Cut(segment, "#")
+	return relative, strings.HasSuffix(base, "/") && !strings.Contains(segment, ":") && segment != "." && segment != ".."
+}
+
+// checkIRI fails if the IRI, or an IRI of the quoted triple, contains
+// a space, a control character or one of <>"{}|^`\ which cannot be
+// written in an IRI reference, not even escaped.
+func checkIRI(str string) error {
+	if q, ok := quotedTriple(str); ok {
+		return checkTerm(q)
+	}
+
+	if isBlankNode(str) {
+		return nil
+	}
+
+	if strings.IndexFunc(str, isInvalidIRIRefChar) >= 0 {
+		return fmt.Errorf("%w: %q", ErrInvalidIRI, str)
+	}
+
+	return nil
+}
 
-		return fmt.Sprintf("<%s>", str)
+func checkTerm(t rdf.Term) error {
+	switch t := t.(type) {
+	case rdf.IRI:
+		return checkIRI(string(t))
+	case rdf.QuotedTriple:
+		for _, term := range []rdf.Term{t.Subject, t.Predicate, t.Object} {
+			if err := checkTerm(term); err != nil {
+				return err
+			}
+		}
 	}
 
-	edge := literalEdge(str)
-	return fmt.Sprintf("%s%s%s", edge, str, edge)
+	return nil
+}
+
+func isInvalidIRIRefChar(char rune) bool {
+	return char <= ' ' || strings.ContainsRune(`<>"{}|^`+"`"+`\\`, char)
 }
 
 func isBlankNode(str string) bool {
@@ -104,19 +234,3 @@ func isValidIRIChar(char rune) bool {
 }
 
 // TODO consts
-
-func literalEdge(str string) string {
-	if !strings.ContainsRune(str, runeNewLine) {
-		if !strings.ContainsRune(str, runeQuotation) {
-			return `"`
-		}
-
-		return `'`
-	}
-
-	if strings.ContainsRune(str, runeApostrophe) {
-		return `"""`
-	}
-
-	return `'''`
-}
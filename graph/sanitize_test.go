@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/nvkp/turtle/assert"
+	"github.com/nvkp/turtle/rdf"
 )
 
 var sanitizesTestCases = map[string]struct {
@@ -11,6 +12,7 @@ var sanitizesTestCases = map[string]struct {
 	expected  string
 	typ       string
 	predicate bool
+	style     QuoteStyle
 }{
 	"empty_string": {
 		str:      "",
@@ -53,6 +55,56 @@ literal`,
 literal'''`,
 		typ: "literal",
 	},
+	"literal_escapes": {
+		str:      "tab\t, backslash \\ and \"quotes\" 'both'",
+		expected: "\"tab\t, backslash \\\\ and \\\"quotes\\\" 'both'\"",
+		typ:      "literal",
+	},
+	"literal_fewer_apostrophes": {
+		str:      `"quotes" and 'one`,
+		expected: `'"quotes" and \'one'`,
+		typ:      "literal",
+	},
+	"literal_control_characters": {
+		str:      "carriage\rreturn, bell \a",
+		expected: `"carriage\rreturn, bell \u0007"`,
+		typ:      "literal",
+	},
+	"multiline_literal_escapes": {
+		str:      "this is a\n\tliteral ending with '",
+		expected: "\"\"\"this is a\n\tliteral ending with '\"\"\"",
+		typ:      "literal",
+	},
+	"multiline_literal_both_quotes": {
+		str: "this is \"a\"\nliteral ending with '",
+		expected: `"""this is "a"
+literal ending with '"""`,
+		typ: "literal",
+	},
+	"multiline_literal_quote_runs": {
+		str: "\"\"\"\"\"\n and '''",
+		expected: `"""""\"""
+ and '''"""`,
+		typ: "literal",
+	},
+	"multiline_literal_ending_quotes": {
+		str: "'''\n\"\"",
+		expected: `"""'''
+"\""""`,
+		typ: "literal",
+	},
+	"double_quotes": {
+		str:      "it's \"a\"\nliteral",
+		expected: `"it's \"a\"\nliteral"`,
+		typ:      "literal",
+		style:    QuoteDouble,
+	},
+	"ntriples_quotes": {
+		str:      "it's \"a\"\n\tČeská \\ literal\x7f",
+		expected: `"it's \"a\"\n\tČeská \\ literal\u007F"`,
+		typ:      "literal",
+		style:    QuoteNTriples,
+	},
 	"a, not predicate": {
 		str:      "a",
 		expected: "<a>",
@@ -67,11 +119,147 @@ literal'''`,
 }
 
 func TestSanitize(t *testing.T) {
-	g := New()
 	for name, tc := range sanitizesTestCases {
 		t.Run(name, func(t *testing.T) {
+			g := NewWithOptions(Options{Quotes: tc.style})
 			actual := g.sanitize(tc.str, tc.typ, tc.predicate)
 			assert.Equal(t, tc.expected, actual, "function should have returned correctly sanitized string")
 		})
 	}
 }
+
+var sanitizeObjectTestCases = map[string]struct {
+	item     string
+	datatype string
+	style    QuoteStyle
+	expected string
+}{
+	"integer": {
+		item:     "-42",
+		datatype: rdf.XSDInteger,
+		expected: "-42",
+	},
+	"non_canonical_integer": {
+		item:     "+042",
+		datatype: rdf.XSDInteger,
+		expected: `"+042"^^<http://www.w3.org/2001/XMLSchema#integer>`,
+	},
+	"decimal": {
+		item:     "4.2",
+		datatype: rdf.XSDDecimal,
+		expected: "4.2",
+	},
+	"non_canonical_decimal": {
+		item:     "4.20",
+		datatype: rdf.XSDDecimal,
+		expected: `"4.20"^^<http://www.w3.org/2001/XMLSchema#decimal>`,
+	},
+	"double": {
+		item:     "4.2E0",
+		datatype: rdf.XSDDouble,
+		expected: "4.2E0",
+	},
+	"double_infinity": {
+		item:     "INF",
+		datatype: rdf.XSDDouble,
+		expected: `"INF"^^<http://www.w3.org/2001/XMLSchema#double>`,
+	},
+	"boolean": {
+		item:     "true",
+		datatype: rdf.XSDBoolean,
+		expected: "true",
+	},
+	"non_canonical_boolean": {
+		item:     "1",
+		datatype: rdf.XSDBoolean,
+		expected: `"1"^^<http://www.w3.org/2001/XMLSchema#boolean>`,
+	},
+	"other_datatype": {
+		item:     "42",
+		datatype: "http://www.w3.org/2001/XMLSchema#positiveInteger",
+		expected: `"42"^^<http://www.w3.org/2001/XMLSchema#positiveInteger>`,
+	},
+	"ntriples": {
+		item:     "42",
+		datatype: rdf.XSDInteger,
+		style:    QuoteNTriples,
+		expected: `"42"^^<http://www.w3.org/2001/XMLSchema#integer>`,
+	},
+}
+
+func TestSanitizeObject(t *testing.T) {
+	for name, tc := range sanitizeObjectTestCases {
+		t.Run(name, func(t *testing.T) {
+			g := NewWithOptions(Options{Quotes: tc.style})
+			actual := g.sanitizeObject(object{item: tc.item, datatype: tc.datatype, typ: "literal"})
+			assert.Equal(t, tc.expected, actual, "function should have returned correctly sanitized literal")
+		})
+	}
+}
+
+var relativizeTestCases = map[string]struct {
+	base     string
+	iri      string
+	expected string
+}{
+	"same_as_base": {
+		base:     "http://example.org",
+		iri:      "http://example.org",
+		expected: "<>",
+	},
+	"path_after_trailing_slash": {
+		base:     "http://example.org/people/",
+		iri:      "http://example.org/people/Mark_Twain",
+		expected: "<Mark_Twain>",
+	},
+	"absolute_path_after_authority": {
+		base:     "http://example.org",
+		iri:      "http://example.org/people/Mark_Twain",
+		expected: "</people/Mark_Twain>",
+	},
+	"path_without_trailing_slash": {
+		base:     "http://example.org/people",
+		iri:      "http://example.org/peopleMark_Twain",
+		expected: "<http://example.org/peopleMark_Twain>",
+	},
+	"absolute_path_after_path": {
+		base:     "http://example.org/people",
+		iri:      "http://example.org/people/Mark_Twain",
+		expected: "<http://example.org/people/Mark_Twain>",
+	},
+	"fragment": {
+		base:     "http://example.org/people",
+		iri:      "http://example.org/people#Mark_Twain",
+		expected: "<#Mark_Twain>",
+	},
+	"base_with_fragment": {
+		base:     "http://example.org/people#",
+		iri:      "http://example.org/people#Mark_Twain",
+		expected: "<http://example.org/people#Mark_Twain>",
+	},
+	"query": {
+		base:     "http://example.org/people",
+		iri:      "http://example.org/people?name=Mark_Twain",
+		expected: "<?name=Mark_Twain>",
+	},
+	"segment_like_scheme": {
+		base:     "http://example.org/people/",
+		iri:      "http://example.org/people/name:Mark_Twain",
+		expected: "<http://example.org/people/name:Mark_Twain>",
+	},
+	"dot_segment": {
+		base:     "http://example.org/people/",
+		iri:      "http://example.org/people/../Mark_Twain",
+		expected: "<http://example.org/people/../Mark_Twain>",
+	},
+}
+
+func TestRelativize(t *testing.T) {
+	for name, tc := range relativizeTestCases {
+		t.Run(name, func(t *testing.T) {
+			g := NewWithOptions(Options{Base: tc.base})
+			actual := g.sanitize(tc.iri, "iri", false)
+			assert.Equal(t, tc.expected, actual, "function should have returned correctly relativized IRI")
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nvkp/turtle/rdf"
+)
+
+// ErrInvalidTerm is returned when a term of the added triple
+// cannot stand in its position.
+var ErrInvalidTerm = errors.New("invalid term")
+
+// Add stores a new triple made of RDF terms to the graph.
+func (g *Graph) Add(t rdf.Triple) error {
+	parts, err := tripleParts(t)
+	if err != nil {
+		return err
+	}
+
+	return g.AcceptWithAnnotations(parts)
+}
+
+// statement returns the triple of the graph made of RDF terms.
+func statement(subject string, predicate string, obj object) rdf.Triple {
+	return rdf.Triple{Subject: rdf.Resource(subject), Predicate: predicateIRI(predicate), Object: objectTerm(obj)}
+}
+
+// predicateIRI returns the predicate as an IRI, rdf:type for the keyword a.
+func predicateIRI(predicate string) rdf.IRI {
+	if predicate == "a" {
+		return rdfTypeIRI
+	}
+
+	return rdf.IRI(predicate)
+}
+
+// objectTerm returns the object as either a resource or a literal.
+func objectTerm(obj object) rdf.Term {
+	switch {
+	case obj.typ == "iri", obj.typ == "triple", obj.typ != "literal" && (isBlankNode(obj.item) || isIRI(obj.item) || rdf.IsQuotedTriple(obj.item)):
+		return rdf.Resource(obj.item)
+	}
+
+	lang, direction := rdf.SplitLanguageTag(obj.label)
+	return rdf.Literal{Value: obj.item, Lang: lang, Direction: direction, Datatype: rdf.IRI(obj.datatype)}
+}
+
+// quotedTriple parses the quoted triple written in its N-Triples form.
+func quotedTriple(value string) (rdf.QuotedTriple, bool) {
+	if !rdf.IsQuotedTriple(value) {
+		return rdf.QuotedTriple{}, false
+	}
+
+	t, err := rdf.ParseTerm(value)
+	q, ok := t.(rdf.QuotedTriple)
+	return q, err == nil && ok
+}
+
+// graphName returns the graph name term as the string the dataset
+// uses for it, an empty string for the default graph.
+func graphName(t rdf.Term) (string, error) {
+	switch g := t.(type) {
+	case nil:
+		return "", nil
+	case rdf.IRI:
+		return string(g), checkIRI(string(g))
+	case rdf.BlankNode:
+		return g.String(), nil
+	}
+
+	return "", fmt.Errorf("%w as graph name: %v", ErrInvalidTerm, t)
+}
+
+// tripleParts returns the triple as the subject, predicate, object,
+// label, data type and object type the graph consumes.
+func tripleParts(t rdf.Triple) ([6]string, error) {
+	var parts [6]string
+
+	subject, ok := subjectKey(t.Subject)
+	if !ok {
+		return parts, fmt.Errorf("%w as subject: %v", ErrInvalidTerm, t.Subject)
+	}
+	parts[0] = subject
+
+	p, ok := t.Predicate.(rdf.IRI)
+	if !ok {
+		return parts, fmt.Errorf("%w as predicate: %v", ErrInvalidTerm, t.Predicate)
+	}
+	parts[1] = string(p)
+
+	switch o := t.Object.(type) {
+	case rdf.IRI:
+		parts[2], parts[5] = string(o), "iri"
+	case rdf.BlankNode:
+		parts[2], parts[5] = o.String(), "iri"
+	case rdf.QuotedTriple:
+		parts[2], parts[5] = o.String(), "triple"
+	case rdf.Literal:
+		parts[2], parts[3], parts[4], parts[5] = o.Value, rdf.JoinLanguageTag(o.Lang, o.Direction), string(o.Datatype), "literal"
+	default:
+		return parts, fmt.Errorf("%w as object: %v", ErrInvalidTerm, t.Object)
+	}
+
+	return parts, nil
+}
+
+// subjectKey returns the subject term as the string the graph
+// stores it as. It fails unless the term can be a subject.
+func subjectKey(t rdf.Term) (string, bool) {
+	switch s := t.(type) {
+	case rdf.IRI:
+		return string(s), true
+	case rdf.BlankNode, rdf.QuotedTriple:
+		return s.String(), true
+	}
+
+	return "", false
+}
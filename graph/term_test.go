@@ -0,0 +1,147 @@
+package graph_test
+
+import (
+	"testing"
+
+	"github.com/nvkp/turtle/assert"
+	"github.com/nvkp/turtle/graph"
+	"github.com/nvkp/turtle/rdf"
+)
+
+var addTestCases = map[string]struct {
+	triple   rdf.Triple
+	expected string
+	expErr   error
+}{
+	"iri_object": {
+		triple: rdf.Triple{
+			Subject:   rdf.IRI("http://example.org/alice"),
+			Predicate: rdf.IRI("http://example.org/knows"),
+			Object:    rdf.IRI("http://example.org/bob"),
+		},
+		expected: `<http://example.org/alice> <http://example.org/knows> <http://example.org/bob> .
+`,
+	},
+	"literal_object": {
+		triple: rdf.Triple{
+			Subject:   rdf.BlankNode("b0"),
+			Predicate: rdf.IRI("http://example.org/name"),
+			Object:    rdf.Literal{Value: "Alice", Lang: "en"},
+		},
+		expected: `[] <http://example.org/name> "Alice"@en .
+`,
+	},
+	"directional_object": {
+		triple: rdf.Triple{
+			Subject:   rdf.IRI("http://example.org/alice"),
+			Predicate: rdf.IRI("http://example.org/name"),
+			Object:    rdf.Literal{Value: "أليس", Lang: "ar", Direction: rdf.DirectionRTL},
+		},
+		expected: `<http://example.org/alice> <http://example.org/name> "أليس"@ar--rtl .
+`,
+	},
+	"typed_object": {
+		triple: rdf.Triple{
+			Subject:   rdf.IRI("http://example.org/alice"),
+			Predicate: rdf.IRI("http://example.org/born"),
+			Object:    rdf.Literal{Value: "1990-01-01", Datatype: "http://www.w3.org/2001/XMLSchema#date"},
+		},
+		expected: `<http://example.org/alice> <http://example.org/born> "1990-01-01"^^<http://www.w3.org/2001/XMLSchema#date> .
+`,
+	},
+	"quoted_triple_subject": {
+		triple: rdf.Triple{
+			Subject: rdf.QuotedTriple{
+				Subject:   rdf.IRI("http://example.org/alice"),
+				Predicate: rdf.IRI("http://example.org/knows"),
+				Object:    rdf.BlankNode("b0"),
+			},
+			Predicate: rdf.IRI("http://example.org/certainty"),
+			Object:    rdf.Literal{Value: "0.9", Datatype: "http://www.w3.org/2001/XMLSchema#decimal"},
+		},
+		expected: `<< <http://example.org/alice> <http://example.org/knows> _:b0 >> <http://example.org/certainty> 0.9 .
+`,
+	},
+	"nested_quoted_triple_object": {
+		triple: rdf.Triple{
+			Subject:   rdf.IRI("http://example.org/bob"),
+			Predicate: rdf.IRI("http://example.org/doubts"),
+			Object: rdf.QuotedTriple{
+				Subject: rdf.QuotedTriple{
+					Subject:   rdf.IRI("http://example.org/alice"),
+					Predicate: rdf.IRI("http://example.org/name"),
+					Object:    rdf.Literal{Value: "Alice", Lang: "en"},
+				},
+				Predicate: rdf.IRI("http://example.org/source"),
+				Object:    rdf.IRI("http://example.org/census"),
+			},
+		},
+		expected: `<http://example.org/bob> <http://example.org/doubts> << << <http://example.org/alice> <http://example.org/name> "Alice"@en >> <http://example.org/source> <http://example.org/census> >> .
+`,
+	},
+	"literal_subject": {
+		triple: rdf.Triple{
+			Subject:   rdf.Literal{Value: "Alice"},
+			Predicate: rdf.IRI("http://example.org/name"),
+			Object:    rdf.Literal{Value: "Alice"},
+		},
+		expErr: graph.ErrInvalidTerm,
+	},
+	"blank_node_predicate": {
+		triple: rdf.Triple{
+			Subject:   rdf.IRI("http://example.org/alice"),
+			Predicate: rdf.BlankNode("b0"),
+			Object:    rdf.Literal{Value: "Alice"},
+		},
+		expErr: graph.ErrInvalidTerm,
+	},
+	"no_object": {
+		triple: rdf.Triple{
+			Subject:   rdf.IRI("http://example.org/alice"),
+			Predicate: rdf.IRI("http://example.org/name"),
+		},
+		expErr: graph.ErrInvalidTerm,
+	},
+	"space_in_subject": {
+		triple: rdf.Triple{
+			Subject:   rdf.IRI("http://example.org/a b"),
+			Predicate: rdf.IRI("http://example.org/name"),
+			Object:    rdf.Literal{Value: "Alice"},
+		},
+		expErr: graph.ErrInvalidIRI,
+	},
+	"angle_bracket_in_object": {
+		triple: rdf.Triple{
+			Subject:   rdf.IRI("http://example.org/alice"),
+			Predicate: rdf.IRI("http://example.org/knows"),
+			Object:    rdf.IRI("http://example.org/<bob>"),
+		},
+		expErr: graph.ErrInvalidIRI,
+	},
+	"space_in_quoted_triple": {
+		triple: rdf.Triple{
+			Subject: rdf.QuotedTriple{
+				Subject:   rdf.IRI("http://example.org/alice"),
+				Predicate: rdf.IRI("http://example.org/knows"),
+				Object:    rdf.IRI("http://example.org/b ob"),
+			},
+			Predicate: rdf.IRI("http://example.org/certainty"),
+			Object:    rdf.Literal{Value: "0.9", Datatype: "http://www.w3.org/2001/XMLSchema#decimal"},
+		},
+		expErr: graph.ErrInvalidIRI,
+	},
+}
+
+func TestAdd(t *testing.T) {
+	for name, tc := range addTestCases {
+		t.Run(name, func(t *testing.T) {
+			g := graph.New()
+			err := g.Add(tc.triple)
+			assert.ErrorIs(t, err, tc.expErr, "method should have returned a correct error")
+
+			b, err := g.Bytes()
+			assert.NoError(t, err, "no error was expected")
+			assert.Equal(t, tc.expected, string(b), "method should have stored the triple")
+		})
+	}
+}
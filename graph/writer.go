@@ -0,0 +1,149 @@
+package graph
+
+import (
+	"io"
+
+	"github.com/nvkp/turtle/rdf"
+)
+
+// Writer consumes triples one by one the same way as Graph does,
+// but instead of keeping all of them in memory it writes them
+// to an io.Writer as Turtle data. The @base and @prefix forms
+// are written first, followed by the subject blocks.
+//
+// With the insertion order set in the options, a subject block
+// is written as soon as a triple of a different subject is consumed.
+// Otherwise the triples are kept until Flush is called so that
+// they can be sorted. As a blank node can be referenced by the triples
+// consumed after a flush, the blank nodes are always written with their
+// labels instead of being inlined the way Graph.Bytes does.
+//
+// The triples of named graphs are consumed the same way as Dataset does
+// them and can only be written with the N-Quads or TriG format set in the
+// options. In TriG the block of a named graph is left open as long as its
+// subjects follow one another in the insertion order and is closed by Flush.
+type Writer struct {
+	d         *Dataset
+	w         io.Writer
+	last      string
+	lastGraph string
+	// block is the name of the graph whose TriG block is open
+	block  string
+	header bool
+	err    error
+}
+
+// NewWriter returns a new graph.Writer writing to w.
+// See Options.
+func NewWriter(w io.Writer, options Options) *Writer {
+	return &Writer{
+		d: NewDatasetWithOptions(options),
+		w: w,
+	}
+}
+
+// Accept consumes a new triple.
+func (w *Writer) Accept(t [3]string) error {
+	return w.AcceptWithAnnotations([6]string{t[0], t[1], t[2]})
+}
+
+// AcceptWithAnnotations consumes a new triple with eventual label
+// and data type of the object literal.
+func (w *Writer) AcceptWithAnnotations(t [6]string) error {
+	return w.AcceptInGraph("", t)
+}
+
+// AcceptInGraph consumes a new triple with eventual label and data type
+// of the object literal belonging to the graph of the given name,
+// the default graph for an empty name.
+func (w *Writer) AcceptInGraph(name string, t [6]string) error {
+	if w.err != nil {
+		return w.err
+	}
+
+	// the subject block is complete when the subject or its graph changes
+	if w.d.options.Order == OrderInsertion && w.last != "" && (w.last != t[0] || w.lastGraph != name) {
+		if err := w.flush(false); err != nil {
+			return err
+		}
+	}
+	w.last, w.lastGraph = t[0], name
+
+	return w.d.AcceptInGraph(name, t)
+}
+
+// Add consumes a new triple made of RDF terms.
+func (w *Writer) Add(t rdf.Triple) error {
+	parts, err := tripleParts(t)
+	if err != nil {
+		return err
+	}
+
+	return w.AcceptWithAnnotations(parts)
+}
+
+// AddQuad consumes a new triple made of RDF terms belonging
+// to the graph of the quad's graph name.
+func (w *Writer) AddQuad(q rdf.Quad) error {
+	name, err := graphName(q.Graph)
+	if err != nil {
+		return err
+	}
+
+	parts, err := tripleParts(q.Triple())
+	if err != nil {
+		return err
+	}
+
+	return w.AcceptInGraph(name, parts)
+}
+
+// Flush writes the @base and @prefix forms, if they were not written
+// yet, and all the so far consumed triples to the underlying writer.
+// When only the used prefixes are output, the triples consumed after
+// the first flush can use only the prefixes it has written.
+func (w *Writer) Flush() error {
+	return w.flush(true)
+}
+
+// flush writes the so far consumed triples, closing the open
+// block of a named graph only if the block is complete.
+func (w *Writer) flush(complete bool) error {
+	if w.err != nil {
+		return w.err
+	}
+
+	// the subjects are written first to know what prefixes they use
+	var body []byte
+	switch {
+	case w.d.options.Format == FormatNQuads:
+		w.d.writeQuads(&body)
+	case w.d.options.Format == FormatTriG:
+		w.d.writeBlocks(&body, &w.block)
+		if complete {
+			closeBlock(&body, &w.block)
+		}
+	case w.d.hasNamedGraphs():
+		w.err = ErrNamedGraphs
+		return w.err
+	default:
+		w.d.def.writeSubjects(&body)
+	}
+	w.d.reset()
+
+	var b []byte
+	if !w.header {
+		w.d.def.writePragmas(&b)
+		// the later triples can only use the prefixes already written
+		w.d.def.prefixes = w.d.def.declaredPrefixes()
+		w.header = true
+	}
+	b = append(b, body...)
+
+	if len(b) == 0 {
+		return nil
+	}
+
+	_, w.err = w.w.Write(b)
+	return w.err
+}
@@ -0,0 +1,215 @@
+package graph_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/nvkp/turtle/assert"
+	"github.com/nvkp/turtle/graph"
+	"github.com/nvkp/turtle/rdf"
+)
+
+var writerTestCases = map[string]struct {
+	options   graph.Options
+	triples   [][3]string
+	beforeEnd string
+	expected  string
+}{
+	"sorted": {
+		triples: [][3]string{
+			{"c", "d", "e"},
+			{"a", "b", "c"},
+			{"a", "b", "a"},
+		},
+		beforeEnd: "",
+		expected: `<a> <b> "a", "c" .
+<c> <d> "e" .
+`,
+	},
+	"insertion_order": {
+		options: graph.Options{Order: graph.OrderInsertion},
+		triples: [][3]string{
+			{"c", "d", "e"},
+			{"a", "b", "c"},
+			{"a", "b", "a"},
+		},
+		beforeEnd: `<c> <d> "e" .
+`,
+		expected: `<c> <d> "e" .
+<a> <b> "c", "a" .
+`,
+	},
+	"pragmas": {
+		options: graph.Options{
+			Base:  "http://example.org/",
+			Order: graph.OrderInsertion,
+		},
+		triples: [][3]string{
+			{"http://example.org/a", "http://example.org/b", "c"},
+			{"http://example.org/d", "http://example.org/e", "f"},
+		},
+		beforeEnd: `@base <http://example.org/> .
+<a> <b> "c" .
+`,
+		expected: `@base <http://example.org/> .
+<a> <b> "c" .
+<d> <e> "f" .
+`,
+	},
+	"ntriples": {
+		options: graph.Options{
+			Prefixes: map[string]string{"ex": "http://example.org/"},
+			Order:    graph.OrderInsertion,
+			Format:   graph.FormatNTriples,
+		},
+		triples: [][3]string{
+			{"http://example.org/a", "http://example.org/b", "c"},
+			{"http://example.org/a", "http://example.org/d", "e"},
+			{"http://example.org/f", "http://example.org/g", "http://example.org/h"},
+		},
+		beforeEnd: `<http://example.org/a> <http://example.org/b> "c" .
+<http://example.org/a> <http://example.org/d> "e" .
+`,
+		expected: `<http://example.org/a> <http://example.org/b> "c" .
+<http://example.org/a> <http://example.org/d> "e" .
+<http://example.org/f> <http://example.org/g> <http://example.org/h> .
+`,
+	},
+	"used_prefixes": {
+		options: graph.Options{
+			Prefixes: map[string]string{
+				"ex":     "http://example.org/",
+				"foaf":   "http://xmlns.com/foaf/0.1/",
+				"schema": "https://schema.org/",
+				"dc":     "http://purl.org/dc/elements/1.1/",
+			},
+			OmitUnusedPrefixes: true,
+			Order:              graph.OrderInsertion,
+		},
+		triples: [][3]string{
+			{"http://example.org/a", "http://xmlns.com/foaf/0.1/name", "c"},
+			{"http://example.org/d", "https://schema.org/name", "f"},
+		},
+		beforeEnd: `@prefix ex: <http://example.org/> .
+@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+ex:a foaf:name "c" .
+`,
+		expected: `@prefix ex: <http://example.org/> .
+@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+ex:a foaf:name "c" .
+ex:d <https://schema.org/name> "f" .
+`,
+	},
+}
+
+func TestWriter(t *testing.T) {
+	for name, tc := range writerTestCases {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := graph.NewWriter(&buf, tc.options)
+
+			for _, triple := range tc.triples {
+				err := w.Accept(triple)
+				assert.NoError(t, err, "method Accept should have returned no error")
+			}
+
+			assert.Equal(t, tc.beforeEnd, buf.String(), "writer should have written only the completed subject blocks")
+
+			err := w.Flush()
+			assert.NoError(t, err, "method Flush should have returned no error")
+			assert.Equal(t, tc.expected, buf.String(), "writer should have written all the triples")
+		})
+	}
+}
+
+type failingWriter struct{}
+
+var errWrite = errors.New("write failed")
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errWrite
+}
+
+func TestWriterError(t *testing.T) {
+	w := graph.NewWriter(failingWriter{}, graph.Options{Order: graph.OrderInsertion})
+
+	_ = w.Accept([3]string{"a", "b", "c"})
+	err := w.Accept([3]string{"d", "e", "f"})
+	assert.ErrorIs(t, err, errWrite, "method Accept should have returned the write error")
+
+	err = w.Flush()
+	assert.ErrorIs(t, err, errWrite, "method Flush should have kept returning the write error")
+}
+
+func TestWriterQuads(t *testing.T) {
+	var buf bytes.Buffer
+	w := graph.NewWriter(&buf, graph.Options{Order: graph.OrderInsertion, Format: graph.FormatNQuads})
+
+	quads := []rdf.Quad{
+		{Subject: rdf.IRI("http://example.org/a"), Predicate: rdf.IRI("http://example.org/b"), Object: rdf.Literal{Value: "c"}, Graph: rdf.IRI("http://example.org/g")},
+		{Subject: rdf.IRI("http://example.org/a"), Predicate: rdf.IRI("http://example.org/b"), Object: rdf.Literal{Value: "d"}},
+	}
+	for _, quad := range quads {
+		err := w.AddQuad(quad)
+		assert.NoError(t, err, "no error was expected")
+	}
+
+	assert.Equal(t, "<http://example.org/a> <http://example.org/b> \"c\" <http://example.org/g> .\n", buf.String(), "the quad should have been written when the graph changed")
+
+	err := w.Flush()
+	assert.NoError(t, err, "no error was expected")
+	assert.Equal(t, `<http://example.org/a> <http://example.org/b> "c" <http://example.org/g> .
+<http://example.org/a> <http://example.org/b> "d" .
+`, buf.String(), "writer should have written all the quads")
+
+	w = graph.NewWriter(&buf, graph.Options{})
+	err = w.AcceptInGraph("http://example.org/g", [6]string{"a", "b", "c"})
+	assert.NoError(t, err, "no error was expected")
+	err = w.Flush()
+	assert.ErrorIs(t, err, graph.ErrNamedGraphs, "named graph cannot be written as Turtle")
+}
+
+func TestWriterTriG(t *testing.T) {
+	var buf bytes.Buffer
+	w := graph.NewWriter(&buf, graph.Options{
+		Prefixes: map[string]string{"ex": "http://example.org/"},
+		Order:    graph.OrderInsertion,
+		Format:   graph.FormatTriG,
+	})
+
+	triples := []struct {
+		graph  string
+		triple [6]string
+	}{
+		{"http://example.org/g", [6]string{"http://example.org/a", "http://example.org/b", "c"}},
+		{"http://example.org/g", [6]string{"http://example.org/d", "http://example.org/b", "e"}},
+		{"", [6]string{"http://example.org/a", "http://example.org/b", "f"}},
+		{"http://example.org/h", [6]string{"http://example.org/a", "http://example.org/b", "g"}},
+	}
+	for _, triple := range triples {
+		err := w.AcceptInGraph(triple.graph, triple.triple)
+		assert.NoError(t, err, "no error was expected")
+	}
+
+	assert.Equal(t, `@prefix ex: <http://example.org/> .
+ex:g {
+ex:a ex:b "c" .
+ex:d ex:b "e" .
+}
+ex:a ex:b "f" .
+`, buf.String(), "writer should have kept the block open while its subjects followed")
+
+	err := w.Flush()
+	assert.NoError(t, err, "no error was expected")
+	assert.Equal(t, `@prefix ex: <http://example.org/> .
+ex:g {
+ex:a ex:b "c" .
+ex:d ex:b "e" .
+}
+ex:a ex:b "f" .
+ex:h {
+ex:a ex:b "g" .
+}
+`, buf.String(), "writer should have closed the last block")
+}
@@ -0,0 +1,110 @@
+package turtle
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrInvalidList is returned by Unmarshal when the RDF collection
+// of a `turtle:",list"` field is malformed, e.g. it contains a cycle
+// or one of its nodes has several rdf:first values
+var ErrInvalidList = errors.New("invalid RDF collection")
+
+// marshalList extracts the triples of the RDF collection holding
+// the elements of the slice or the array. A nil slice is omitted,
+// an empty one is written as rdf:nil.
+func (m *marshaller) marshalList(id string, iri string, v reflect.Value) error {
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return fmt.Errorf("%w: %s", ErrInvalidValueType, v.Type())
+	}
+
+	if v.Kind() == reflect.Slice && v.IsNil() {
+		return nil
+	}
+
+	head, previous := rdfNilIRI, ""
+	for i := 0; i < v.Len(); i++ {
+		node := m.newBlankNode()
+
+		ok, err := m.marshalObject(node, rdfFirstIRI, v.Index(i))
+		if err != nil {
+			return err
+		}
+		// the empty elements are left out
+		if !ok {
+			continue
+		}
+
+		if previous == "" {
+			head = node
+		} else if err := m.g.AcceptWithAnnotations([6]string{previous, rdfRestIRI, node, "", "", TypeIRI}); err != nil {
+			return err
+		}
+		previous = node
+	}
+
+	if previous != "" {
+		if err := m.g.AcceptWithAnnotations([6]string{previous, rdfRestIRI, rdfNilIRI, "", "", TypeIRI}); err != nil {
+			return err
+		}
+	}
+
+	return m.g.AcceptWithAnnotations([6]string{id, iri, head, "", "", TypeIRI})
+}
+
+// unmarshalList follows the rdf:first and rdf:rest chain starting
+// at the head and sets its elements to the slice in their order.
+func (rs *resources) unmarshalList(field reflect.Value, head string) error {
+	if field.Kind() != reflect.Slice {
+		return fmt.Errorf("%w: %s", ErrInvalidValueType, field.Type())
+	}
+
+	items := reflect.MakeSlice(field.Type(), 0, 0)
+	visited := make(map[string]bool)
+
+	for node := head; node != rdfNilIRI; {
+		if visited[node] {
+			return fmt.Errorf("%w: cycle at %s", ErrInvalidList, node)
+		}
+		visited[node] = true
+
+		r, ok := rs.index[node]
+		if !ok {
+			return fmt.Errorf("%w: %s is not a list node", ErrInvalidList, node)
+		}
+
+		var first [6]string
+		var firsts, rests int
+		var rest string
+		for _, t := range r.triples {
+			switch t[predicate] {
+			case rdfFirstIRI:
+				first = t
+				firsts++
+			case rdfRestIRI:
+				rest = t[object]
+				rests++
+			}
+		}
+
+		if firsts != 1 {
+			return fmt.Errorf("%w: %d rdf:first values of %s", ErrInvalidList, firsts, node)
+		}
+
+		if rests != 1 {
+			return fmt.Errorf("%w: %d rdf:rest values of %s", ErrInvalidList, rests, node)
+		}
+
+		item := reflect.New(field.Type().Elem()).Elem()
+		if err := rs.setObject(item, first); err != nil {
+			return err
+		}
+		items = reflect.Append(items, item)
+
+		node = rest
+	}
+
+	field.Set(items)
+	return nil
+}
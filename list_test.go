@@ -0,0 +1,105 @@
+package turtle_test
+
+import (
+	"testing"
+
+	"github.com/nvkp/turtle"
+	"github.com/nvkp/turtle/assert"
+)
+
+type chapter struct {
+	Title string `turtle:"ex:title"`
+}
+
+type publication struct {
+	ID       string    `turtle:"@id"`
+	Authors  []string  `turtle:"ex:authors,list"`
+	Pages    []int     `turtle:"<http://example.org/pages>,list"`
+	Chapters []chapter `turtle:"ex:chapters,list"`
+}
+
+func TestMarshalList(t *testing.T) {
+	p := publication{
+		ID:      "http://example.org/book",
+		Authors: []string{"Mark Twain", "Charles Dudley Warner"},
+		Pages:   []int{},
+	}
+	expected := `@prefix ex: <http://example.org/> .
+@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+ex:book 
+	ex:authors ( "Mark Twain" "Charles Dudley Warner" ) ;
+	ex:pages () .
+`
+
+	b, err := exConfig.Marshal(p)
+	assert.NoError(t, err, "Marshal function should have returned no error")
+	assert.Equal(t, expected, string(b), "Marshal function should have written the slice as a collection")
+}
+
+func TestUnmarshalList(t *testing.T) {
+	data := []byte(`@prefix ex: <http://example.org/> .
+ex:book ex:authors ( "Mark Twain" "Charles Dudley Warner" ) ;
+	ex:pages ( 1 2 3 ) ;
+	ex:chapters ( [ ex:title "Civilizing Huck" ] [ ex:title "The Boys Escape Jim" ] ) .`)
+	expected := publication{
+		ID:      "http://example.org/book",
+		Authors: []string{"Mark Twain", "Charles Dudley Warner"},
+		Pages:   []int{1, 2, 3},
+		Chapters: []chapter{
+			{Title: "Civilizing Huck"},
+			{Title: "The Boys Escape Jim"},
+		},
+	}
+
+	var target []publication
+	err := turtle.Unmarshal(data, &target)
+	assert.NoError(t, err, "Unmarshal function should have returned no error")
+	assert.Equal(t, []publication{expected}, target, "Unmarshal function should have followed the collections")
+}
+
+func TestMarshalUnmarshalList(t *testing.T) {
+	publications := []publication{
+		{
+			ID:       "http://example.org/book",
+			Authors:  []string{"Mark Twain", "Charles Dudley Warner", "Mark Twain"},
+			Pages:    []int{},
+			Chapters: []chapter{{Title: "Civilizing Huck"}},
+		},
+	}
+
+	b, err := exConfig.Marshal(publications)
+	assert.NoError(t, err, "Marshal function should have returned no error")
+
+	var target []publication
+	err = exConfig.Unmarshal(b, &target)
+	assert.NoError(t, err, "Unmarshal function should have returned no error")
+	assert.Equal(t, publications, target, "Unmarshal function should have returned the marshalled collections")
+}
+
+var unmarshalInvalidListTestCases = map[string]string{
+	"cycle": `@prefix ex: <http://example.org/> .
+@prefix rdf: <http://www.w3.org/1999/02/22-rdf-syntax-ns#> .
+ex:book ex:authors _:a .
+_:a rdf:first "Mark Twain" ; rdf:rest _:b .
+_:b rdf:first "Charles Dudley Warner" ; rdf:rest _:a .`,
+	"several_first_values": `@prefix ex: <http://example.org/> .
+@prefix rdf: <http://www.w3.org/1999/02/22-rdf-syntax-ns#> .
+ex:book ex:authors _:a .
+_:a rdf:first "Mark Twain", "Charles Dudley Warner" ; rdf:rest rdf:nil .`,
+	"missing_rest": `@prefix ex: <http://example.org/> .
+@prefix rdf: <http://www.w3.org/1999/02/22-rdf-syntax-ns#> .
+ex:book ex:authors _:a .
+_:a rdf:first "Mark Twain" .`,
+	"not_a_list": `@prefix ex: <http://example.org/> .
+ex:book ex:authors "Mark Twain" .`,
+}
+
+func TestUnmarshalInvalidList(t *testing.T) {
+	for name, data := range unmarshalInvalidListTestCases {
+		t.Run(name, func(t *testing.T) {
+			var target publication
+			err := turtle.Unmarshal([]byte(data), &target)
+			assert.ErrorIs(t, err, turtle.ErrInvalidList, "Unmarshal function should have returned a list error")
+		})
+	}
+}
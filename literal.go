@@ -0,0 +1,424 @@
+package turtle
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nvkp/turtle/rdf"
+)
+
+// The XML Schema datatypes the typed struct fields are mapped to.
+const (
+	XSDNamespace = rdf.XSDNamespace
+	XSDString    = rdf.XSDString
+	XSDBoolean   = rdf.XSDBoolean
+	XSDInteger   = rdf.XSDInteger
+	XSDDecimal   = rdf.XSDDecimal
+	XSDFloat     = XSDNamespace + "float"
+	XSDDouble    = rdf.XSDDouble
+	XSDDateTime  = XSDNamespace + "dateTime"
+	XSDDate      = XSDNamespace + "date"
+	XSDDuration  = XSDNamespace + "duration"
+)
+
+// ErrInvalidLiteral is matched by every LiteralError
+var ErrInvalidLiteral = errors.New("invalid literal")
+
+// LiteralError is returned by Unmarshal when the lexical form of a literal
+// is not valid for the Go type of the `turtle:"object"` field.
+type LiteralError struct {
+	Value    string       // the lexical form of the literal
+	Datatype string       // the datatype IRI of the literal, if any
+	Type     reflect.Type // the type of the field the literal was unmarshalled into
+	Err      error        // the underlying parsing error
+}
+
+func (e *LiteralError) Error() string {
+	if e.Datatype == "" {
+		return fmt.Sprintf("turtle: cannot unmarshal literal %q into Go value of type %s: %v", e.Value, e.Type, e.Err)
+	}
+
+	return fmt.Sprintf("turtle: cannot unmarshal literal %q of datatype %s into Go value of type %s: %v", e.Value, e.Datatype, e.Type, e.Err)
+}
+
+func (e *LiteralError) Unwrap() error {
+	return e.Err
+}
+
+func (e *LiteralError) Is(target error) bool {
+	return target == ErrInvalidLiteral
+}
+
+var (
+	errInvalidBoolean  = errors.New("invalid boolean")
+	errInvalidInteger  = errors.New("invalid integer")
+	errInvalidDecimal  = errors.New("invalid decimal")
+	errInvalidDuration = errors.New("invalid duration")
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+	bigIntType   = reflect.TypeOf((*big.Int)(nil))
+	bigFloatType = reflect.TypeOf((*big.Float)(nil))
+)
+
+// dateTimeLayouts lists the accepted lexical forms of xsd:dateTime
+// and xsd:date, the time zone being optional in both.
+var dateTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02Z07:00",
+	"2006-01-02",
+}
+
+// isTypedLiteral reports whether values of the type are
+// written as literals with an XML Schema datatype.
+func isTypedLiteral(t reflect.Type) bool {
+	switch t {
+	case timeType, durationType, bigIntType, bigFloatType:
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Pointer:
+		return t.Elem().Kind() != reflect.Pointer && isTypedLiteral(t.Elem())
+	}
+
+	return false
+}
+
+// marshalLiteral returns the lexical form and the datatype of the value.
+// A nil pointer results in an empty lexical form.
+func marshalLiteral(v reflect.Value) (string, string) {
+	switch v.Type() {
+	case timeType:
+		return v.Interface().(time.Time).Format(time.RFC3339Nano), XSDDateTime
+	case durationType:
+		return formatDuration(time.Duration(v.Int())), XSDDuration
+	case bigIntType:
+		if v.IsNil() {
+			return "", ""
+		}
+		return v.Interface().(*big.Int).String(), XSDInteger
+	case bigFloatType:
+		if v.IsNil() {
+			return "", ""
+		}
+		return formatDecimal(v.Interface().(*big.Float)), XSDDecimal
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), XSDBoolean
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), XSDInteger
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), XSDInteger
+	case reflect.Float32:
+		return formatDouble(v.Float(), 32), XSDFloat
+	case reflect.Float64:
+		return formatDouble(v.Float(), 64), XSDDouble
+	case reflect.Pointer:
+		if v.IsNil() {
+			return "", ""
+		}
+		return marshalLiteral(v.Elem())
+	}
+
+	return "", ""
+}
+
+// unmarshalLiteral parses the lexical form of the literal
+// into the value according to the value's type.
+func unmarshalLiteral(v reflect.Value, value string, datatype string) error {
+	if v.Kind() == reflect.Pointer && v.Type() != bigIntType && v.Type() != bigFloatType {
+		ptr := reflect.New(v.Type().Elem())
+		if err := unmarshalLiteral(ptr.Elem(), value, datatype); err != nil {
+			return err
+		}
+		v.Set(ptr)
+		return nil
+	}
+
+	if err := parseLiteral(v, strings.TrimSpace(value)); err != nil {
+		return &LiteralError{Value: value, Datatype: datatype, Type: v.Type(), Err: err}
+	}
+
+	return nil
+}
+
+func parseLiteral(v reflect.Value, value string) error {
+	switch v.Type() {
+	case timeType:
+		t, err := parseDateTime(value)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	case durationType:
+		d, err := parseDuration(value)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	case bigIntType:
+		i, ok := new(big.Int).SetString(value, 10)
+		if !ok {
+			return errInvalidInteger
+		}
+		v.Set(reflect.ValueOf(i))
+		return nil
+	case bigFloatType:
+		f, err := parseDecimal(value)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(f))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		switch value {
+		case "true", "1":
+			v.SetBool(true)
+		case "false", "0":
+			v.SetBool(false)
+		default:
+			return errInvalidBoolean
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(strings.TrimPrefix(value, "+"), 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := parseDouble(value, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	}
+
+	return nil
+}
+
+// formatDouble returns the canonical form of xsd:double,
+// e.g. 1.0E0, 4.2E1 or INF.
+func formatDouble(f float64, bitSize int) string {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "INF"
+	case math.IsInf(f, -1):
+		return "-INF"
+	}
+
+	mantissa, exponent, _ := strings.Cut(strconv.FormatFloat(f, 'E', -1, bitSize), "E")
+	if !strings.Contains(mantissa, ".") {
+		mantissa += ".0"
+	}
+
+	exponent = strings.TrimPrefix(exponent, "+")
+	sign := ""
+	if strings.HasPrefix(exponent, "-") {
+		sign, exponent = "-", exponent[1:]
+	}
+	exponent = strings.TrimLeft(exponent, "0")
+	if exponent == "" {
+		exponent, sign = "0", ""
+	}
+
+	return mantissa + "E" + sign + exponent
+}
+
+func parseDouble(value string, bitSize int) (float64, error) {
+	switch value {
+	case "NaN":
+		return math.NaN(), nil
+	case "INF", "+INF":
+		return math.Inf(1), nil
+	case "-INF":
+		return math.Inf(-1), nil
+	}
+
+	return strconv.ParseFloat(value, bitSize)
+}
+
+// formatDecimal returns the xsd:decimal form of the number
+// that always contains a decimal point.
+func formatDecimal(f *big.Float) string {
+	str := f.Text('f', -1)
+	if !strings.Contains(str, ".") {
+		str += ".0"
+	}
+
+	return str
+}
+
+func parseDecimal(value string) (*big.Float, error) {
+	// the precision is high enough to hold all the given digits
+	prec := uint(len(value)) * 4
+	if prec < 64 {
+		prec = 64
+	}
+
+	f, _, err := big.ParseFloat(value, 10, prec, big.ToNearestEven)
+	if err != nil || f.IsInf() {
+		return nil, errInvalidDecimal
+	}
+
+	return f, nil
+}
+
+func parseDateTime(value string) (time.Time, error) {
+	var err error
+	for _, layout := range dateTimeLayouts {
+		var t time.Time
+		if t, err = time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, err
+}
+
+// formatDuration returns the xsd:duration form of the duration
+// expressed in hours, minutes and seconds, e.g. PT1H30M or -PT0.5S.
+func formatDuration(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+
+	var b strings.Builder
+	// the absolute value of the minimal duration does not fit into int64
+	u := uint64(d)
+	if d < 0 {
+		b.WriteByte('-')
+		u = -u
+	}
+	b.WriteString("PT")
+
+	hours := u / uint64(time.Hour)
+	u -= hours * uint64(time.Hour)
+	minutes := u / uint64(time.Minute)
+	u -= minutes * uint64(time.Minute)
+	seconds := u / uint64(time.Second)
+	nanos := u - seconds*uint64(time.Second)
+
+	if hours > 0 {
+		fmt.Fprintf(&b, "%dH", hours)
+	}
+	if minutes > 0 {
+		fmt.Fprintf(&b, "%dM", minutes)
+	}
+	if seconds > 0 || nanos > 0 {
+		fmt.Fprintf(&b, "%d", seconds)
+		if nanos > 0 {
+			fmt.Fprintf(&b, ".%s", strings.TrimRight(fmt.Sprintf("%09d", nanos), "0"))
+		}
+		b.WriteByte('S')
+	}
+
+	return b.String()
+}
+
+// parseDuration parses the xsd:duration lexical form. Days are
+// taken as 24 hours, years and months cannot be represented
+// by a time.Duration and result in an error.
+func parseDuration(value string) (time.Duration, error) {
+	str, negative := strings.CutPrefix(value, "-")
+	str, ok := strings.CutPrefix(str, "P")
+	if !ok || str == "" || strings.HasSuffix(str, "T") {
+		return 0, errInvalidDuration
+	}
+
+	var d time.Duration
+	var inTime bool
+	for str != "" {
+		if str[0] == 'T' {
+			if inTime {
+				return 0, errInvalidDuration
+			}
+			inTime = true
+			str = str[1:]
+			continue
+		}
+
+		i := strings.IndexFunc(str, func(r rune) bool {
+			return (r < '0' || r > '9') && r != '.'
+		})
+		if i <= 0 {
+			return 0, errInvalidDuration
+		}
+
+		number, designator := str[:i], str[i]
+		str = str[i+1:]
+
+		var unit time.Duration
+		switch {
+		case !inTime && designator == 'D':
+			unit = 24 * time.Hour
+		case inTime && designator == 'H':
+			unit = time.Hour
+		case inTime && designator == 'M':
+			unit = time.Minute
+		case inTime && designator == 'S':
+			unit = time.Second
+		case !inTime && (designator == 'Y' || designator == 'M'):
+			return 0, fmt.Errorf("%w: years and months are not supported", errInvalidDuration)
+		default:
+			return 0, errInvalidDuration
+		}
+
+		// only seconds can have a fractional part
+		if strings.Contains(number, ".") && unit != time.Second {
+			return 0, errInvalidDuration
+		}
+
+		whole, fraction, _ := strings.Cut(number, ".")
+		n, err := strconv.ParseInt(whole, 10, 64)
+		if err != nil {
+			return 0, errInvalidDuration
+		}
+		d += time.Duration(n) * unit
+
+		if fraction != "" {
+			// the fraction is cut to nanoseconds
+			fraction = (fraction + "000000000")[:9]
+			nanos, err := strconv.ParseInt(fraction, 10, 64)
+			if err != nil {
+				return 0, errInvalidDuration
+			}
+			d += time.Duration(nanos)
+		}
+	}
+
+	if negative {
+		d = -d
+	}
+
+	return d, nil
+}
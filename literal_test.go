@@ -0,0 +1,381 @@
+package turtle_test
+
+import (
+	"math/big"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/nvkp/turtle"
+	"github.com/nvkp/turtle/assert"
+)
+
+type tripleWithInt struct {
+	Subject   string `turtle:"subject"`
+	Predicate string `turtle:"predicate"`
+	Object    int    `turtle:"object"`
+}
+
+type tripleWithUint8 struct {
+	Subject   string `turtle:"subject"`
+	Predicate string `turtle:"predicate"`
+	Object    uint8  `turtle:"object"`
+}
+
+type tripleWithFloat struct {
+	Subject   string  `turtle:"subject"`
+	Predicate string  `turtle:"predicate"`
+	Object    float64 `turtle:"object"`
+}
+
+type tripleWithFloat32 struct {
+	Subject   string  `turtle:"subject"`
+	Predicate string  `turtle:"predicate"`
+	Object    float32 `turtle:"object"`
+}
+
+type tripleWithBool struct {
+	Subject   string `turtle:"subject"`
+	Predicate string `turtle:"predicate"`
+	Object    bool   `turtle:"object"`
+}
+
+type tripleWithTime struct {
+	Subject   string    `turtle:"subject"`
+	Predicate string    `turtle:"predicate"`
+	Object    time.Time `turtle:"object"`
+}
+
+type tripleWithDuration struct {
+	Subject   string        `turtle:"subject"`
+	Predicate string        `turtle:"predicate"`
+	Object    time.Duration `turtle:"object"`
+}
+
+type tripleWithBigInt struct {
+	Subject   string   `turtle:"subject"`
+	Predicate string   `turtle:"predicate"`
+	Object    *big.Int `turtle:"object"`
+}
+
+type tripleWithBigFloat struct {
+	Subject   string     `turtle:"subject"`
+	Predicate string     `turtle:"predicate"`
+	Object    *big.Float `turtle:"object"`
+}
+
+type tripleWithIntPointer struct {
+	Subject   string `turtle:"subject"`
+	Predicate string `turtle:"predicate"`
+	Object    *int   `turtle:"object"`
+}
+
+type tripleWithIntAndDataType struct {
+	Subject   string `turtle:"subject"`
+	Predicate string `turtle:"predicate"`
+	Object    int    `turtle:"object"`
+	DataType  string `turtle:"datatype"`
+}
+
+var marshalTypedTestCases = map[string]struct {
+	triple    interface{}
+	expString string
+	expErr    error
+}{
+	"int": {
+		triple: tripleWithInt{
+			Subject:   "http://example.org/book/Huckleberry_Finn",
+			Predicate: "http://example.org/relation/pages",
+			Object:    366,
+		},
+		expString: `<http://example.org/book/Huckleberry_Finn> <http://example.org/relation/pages> 366 .
+`,
+	},
+	"negative_int": {
+		triple: tripleWithInt{
+			Subject:   "http://example.org/book/Huckleberry_Finn",
+			Predicate: "http://example.org/relation/pages",
+			Object:    -1,
+		},
+		expString: `<http://example.org/book/Huckleberry_Finn> <http://example.org/relation/pages> -1 .
+`,
+	},
+	"uint8": {
+		triple: tripleWithUint8{
+			Subject:   "http://example.org/book/Huckleberry_Finn",
+			Predicate: "http://example.org/relation/chapters",
+			Object:    43,
+		},
+		expString: `<http://example.org/book/Huckleberry_Finn> <http://example.org/relation/chapters> 43 .
+`,
+	},
+	"float64": {
+		triple: tripleWithFloat{
+			Subject:   "http://example.org/book/Huckleberry_Finn",
+			Predicate: "http://example.org/relation/rating",
+			Object:    42,
+		},
+		expString: `<http://example.org/book/Huckleberry_Finn> <http://example.org/relation/rating> 4.2E1 .
+`,
+	},
+	"float64_fraction": {
+		triple: tripleWithFloat{
+			Subject:   "http://example.org/book/Huckleberry_Finn",
+			Predicate: "http://example.org/relation/rating",
+			Object:    0.000125,
+		},
+		expString: `<http://example.org/book/Huckleberry_Finn> <http://example.org/relation/rating> 1.25E-4 .
+`,
+	},
+	"float32": {
+		triple: tripleWithFloat32{
+			Subject:   "http://example.org/book/Huckleberry_Finn",
+			Predicate: "http://example.org/relation/rating",
+			Object:    1,
+		},
+		expString: `<http://example.org/book/Huckleberry_Finn> <http://example.org/relation/rating> "1.0E0"^^<http://www.w3.org/2001/XMLSchema#float> .
+`,
+	},
+	"bool": {
+		triple: tripleWithBool{
+			Subject:   "http://example.org/book/Huckleberry_Finn",
+			Predicate: "http://example.org/relation/banned",
+			Object:    true,
+		},
+		expString: `<http://example.org/book/Huckleberry_Finn> <http://example.org/relation/banned> true .
+`,
+	},
+	"time": {
+		triple: tripleWithTime{
+			Subject:   "http://example.org/book/Huckleberry_Finn",
+			Predicate: "http://example.org/relation/published",
+			Object:    time.Date(1884, time.December, 10, 12, 30, 0, 0, time.UTC),
+		},
+		expString: `<http://example.org/book/Huckleberry_Finn> <http://example.org/relation/published> "1884-12-10T12:30:00Z"^^<http://www.w3.org/2001/XMLSchema#dateTime> .
+`,
+	},
+	"duration": {
+		triple: tripleWithDuration{
+			Subject:   "http://example.org/book/Huckleberry_Finn",
+			Predicate: "http://example.org/relation/readingTime",
+			Object:    9*time.Hour + 30*time.Minute + 1500*time.Millisecond,
+		},
+		expString: `<http://example.org/book/Huckleberry_Finn> <http://example.org/relation/readingTime> "PT9H30M1.5S"^^<http://www.w3.org/2001/XMLSchema#duration> .
+`,
+	},
+	"negative_duration": {
+		triple: tripleWithDuration{
+			Subject:   "http://example.org/book/Huckleberry_Finn",
+			Predicate: "http://example.org/relation/readingTime",
+			Object:    -time.Minute,
+		},
+		expString: `<http://example.org/book/Huckleberry_Finn> <http://example.org/relation/readingTime> "-PT1M"^^<http://www.w3.org/2001/XMLSchema#duration> .
+`,
+	},
+	"big_int": {
+		triple: tripleWithBigInt{
+			Subject:   "http://example.org/book/Huckleberry_Finn",
+			Predicate: "http://example.org/relation/copies",
+			Object:    new(big.Int).Exp(big.NewInt(10), big.NewInt(20), nil),
+		},
+		expString: `<http://example.org/book/Huckleberry_Finn> <http://example.org/relation/copies> 100000000000000000000 .
+`,
+	},
+	"big_float": {
+		triple: tripleWithBigFloat{
+			Subject:   "http://example.org/book/Huckleberry_Finn",
+			Predicate: "http://example.org/relation/price",
+			Object:    big.NewFloat(12.5),
+		},
+		expString: `<http://example.org/book/Huckleberry_Finn> <http://example.org/relation/price> 12.5 .
+`,
+	},
+	"int_pointer": {
+		triple: tripleWithIntPointer{
+			Subject:   "http://example.org/book/Huckleberry_Finn",
+			Predicate: "http://example.org/relation/pages",
+			Object:    ptr(366),
+		},
+		expString: `<http://example.org/book/Huckleberry_Finn> <http://example.org/relation/pages> 366 .
+`,
+	},
+	"nil_pointer": {
+		triple: tripleWithIntPointer{
+			Subject:   "http://example.org/book/Huckleberry_Finn",
+			Predicate: "http://example.org/relation/pages",
+		},
+		expErr: turtle.ErrNoObjectSpecified,
+	},
+	"explicit_datatype": {
+		triple: tripleWithIntAndDataType{
+			Subject:   "http://example.org/book/Huckleberry_Finn",
+			Predicate: "http://example.org/relation/pages",
+			Object:    366,
+			DataType:  "http://www.w3.org/2001/XMLSchema#positiveInteger",
+		},
+		expString: `<http://example.org/book/Huckleberry_Finn> <http://example.org/relation/pages> "366"^^<http://www.w3.org/2001/XMLSchema#positiveInteger> .
+`,
+	},
+}
+
+func TestMarshalTyped(t *testing.T) {
+	for name, tc := range marshalTypedTestCases {
+		t.Run(name, func(t *testing.T) {
+			b, err := turtle.Marshal(tc.triple)
+			assert.ErrorIs(t, err, tc.expErr, "Marshal function should have returned a correct error")
+			assert.Equal(t, tc.expString, string(b), "Marshal function should have returned a correct byte data")
+		})
+	}
+}
+
+func TestMarshalTypedWithPrefix(t *testing.T) {
+	config := turtle.Config{
+		ResolveURLs: true,
+		Prefixes: map[string]string{
+			"xsd": "http://www.w3.org/2001/XMLSchema#",
+		},
+	}
+	triple := tripleWithIntAndDataType{
+		Subject:   "http://example.org/book/Huckleberry_Finn",
+		Predicate: "http://example.org/relation/pages",
+		Object:    366,
+		DataType:  "http://www.w3.org/2001/XMLSchema#positiveInteger",
+	}
+	expected := `@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+<http://example.org/book/Huckleberry_Finn> <http://example.org/relation/pages> "366"^^xsd:positiveInteger .
+`
+
+	b, err := config.Marshal(triple)
+	assert.NoError(t, err, "Marshal function should have returned no error")
+	assert.Equal(t, expected, string(b), "Marshal function should have compacted the datatype")
+}
+
+func TestUnmarshalTyped(t *testing.T) {
+	subject := "http://example.org/book/Huckleberry_Finn"
+
+	var i tripleWithInt
+	err := turtle.Unmarshal([]byte(`@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+<http://example.org/book/Huckleberry_Finn> <http://example.org/relation/pages> "+366"^^xsd:integer .`), &i)
+	assert.NoError(t, err, "Unmarshal function should have returned no error")
+	assert.Equal(t, tripleWithInt{Subject: subject, Predicate: "http://example.org/relation/pages", Object: 366}, i, "Unmarshal function should have parsed the integer")
+
+	var f tripleWithFloat
+	err = turtle.Unmarshal([]byte(`<http://example.org/book/Huckleberry_Finn> <http://example.org/relation/rating> "-INF"^^<http://www.w3.org/2001/XMLSchema#double> .`), &f)
+	assert.NoError(t, err, "Unmarshal function should have returned no error")
+	assert.Equal(t, true, f.Object < 0 && f.Object*2 == f.Object, "Unmarshal function should have parsed the negative infinity")
+
+	var b tripleWithBool
+	err = turtle.Unmarshal([]byte(`<http://example.org/book/Huckleberry_Finn> <http://example.org/relation/banned> "1"^^<http://www.w3.org/2001/XMLSchema#boolean> .`), &b)
+	assert.NoError(t, err, "Unmarshal function should have returned no error")
+	assert.Equal(t, true, b.Object, "Unmarshal function should have parsed the boolean")
+
+	var tm tripleWithTime
+	err = turtle.Unmarshal([]byte(`<http://example.org/book/Huckleberry_Finn> <http://example.org/relation/published> "1884-12-10T12:30:00.5+01:00"^^<http://www.w3.org/2001/XMLSchema#dateTime> .`), &tm)
+	assert.NoError(t, err, "Unmarshal function should have returned no error")
+	assert.Equal(t, true, tm.Object.Equal(time.Date(1884, time.December, 10, 11, 30, 0, 5e8, time.UTC)), "Unmarshal function should have parsed the date and time")
+
+	var d tripleWithDuration
+	err = turtle.Unmarshal([]byte(`<http://example.org/book/Huckleberry_Finn> <http://example.org/relation/readingTime> "P1DT2H0.25S"^^<http://www.w3.org/2001/XMLSchema#duration> .`), &d)
+	assert.NoError(t, err, "Unmarshal function should have returned no error")
+	assert.Equal(t, 26*time.Hour+250*time.Millisecond, d.Object, "Unmarshal function should have parsed the duration")
+
+	var bi tripleWithBigInt
+	err = turtle.Unmarshal([]byte(`<http://example.org/book/Huckleberry_Finn> <http://example.org/relation/copies> "100000000000000000000"^^<http://www.w3.org/2001/XMLSchema#integer> .`), &bi)
+	assert.NoError(t, err, "Unmarshal function should have returned no error")
+	assert.Equal(t, "100000000000000000000", bi.Object.String(), "Unmarshal function should have parsed the big integer")
+
+	var bf tripleWithBigFloat
+	err = turtle.Unmarshal([]byte(`<http://example.org/book/Huckleberry_Finn> <http://example.org/relation/price> "12.50"^^<http://www.w3.org/2001/XMLSchema#decimal> .`), &bf)
+	assert.NoError(t, err, "Unmarshal function should have returned no error")
+	assert.Equal(t, "12.5", bf.Object.Text('f', -1), "Unmarshal function should have parsed the decimal")
+
+	var p tripleWithIntPointer
+	err = turtle.Unmarshal([]byte(`<http://example.org/book/Huckleberry_Finn> <http://example.org/relation/pages> 366 .`), &p)
+	assert.NoError(t, err, "Unmarshal function should have returned no error")
+	assert.Equal(t, ptr(366), p.Object, "Unmarshal function should have parsed the integer into the pointer")
+}
+
+var unmarshalTypedErrorTestCases = map[string]struct {
+	data   string
+	target interface{}
+	expErr error
+}{
+	"int": {
+		data:   `<http://example.org/book/Huckleberry_Finn> <http://example.org/relation/pages> "many"^^<http://www.w3.org/2001/XMLSchema#integer> .`,
+		target: &tripleWithInt{},
+		expErr: strconv.ErrSyntax,
+	},
+	"uint8_out_of_range": {
+		data:   `<http://example.org/book/Huckleberry_Finn> <http://example.org/relation/chapters> "256"^^<http://www.w3.org/2001/XMLSchema#integer> .`,
+		target: &tripleWithUint8{},
+		expErr: strconv.ErrRange,
+	},
+	"float": {
+		data:   `<http://example.org/book/Huckleberry_Finn> <http://example.org/relation/rating> "high"^^<http://www.w3.org/2001/XMLSchema#double> .`,
+		target: &tripleWithFloat{},
+		expErr: strconv.ErrSyntax,
+	},
+	"bool": {
+		data:   `<http://example.org/book/Huckleberry_Finn> <http://example.org/relation/banned> "yes"^^<http://www.w3.org/2001/XMLSchema#boolean> .`,
+		target: &tripleWithBool{},
+		expErr: turtle.ErrInvalidLiteral,
+	},
+	"time": {
+		data:   `<http://example.org/book/Huckleberry_Finn> <http://example.org/relation/published> "10.12.1884"^^<http://www.w3.org/2001/XMLSchema#dateTime> .`,
+		target: &tripleWithTime{},
+		expErr: turtle.ErrInvalidLiteral,
+	},
+	"duration": {
+		data:   `<http://example.org/book/Huckleberry_Finn> <http://example.org/relation/readingTime> "P1M"^^<http://www.w3.org/2001/XMLSchema#duration> .`,
+		target: &tripleWithDuration{},
+		expErr: turtle.ErrInvalidLiteral,
+	},
+	"big_int": {
+		data:   `<http://example.org/book/Huckleberry_Finn> <http://example.org/relation/copies> "1.5"^^<http://www.w3.org/2001/XMLSchema#integer> .`,
+		target: &tripleWithBigInt{},
+		expErr: turtle.ErrInvalidLiteral,
+	},
+	"big_float": {
+		data:   `<http://example.org/book/Huckleberry_Finn> <http://example.org/relation/price> "cheap"^^<http://www.w3.org/2001/XMLSchema#decimal> .`,
+		target: &tripleWithBigFloat{},
+		expErr: turtle.ErrInvalidLiteral,
+	},
+	"iri": {
+		data:   `<http://example.org/book/Huckleberry_Finn> <http://example.org/relation/pages> <http://example.org/pages> .`,
+		target: &tripleWithInt{},
+		expErr: turtle.ErrInvalidLiteral,
+	},
+}
+
+func TestUnmarshalTypedError(t *testing.T) {
+	for name, tc := range unmarshalTypedErrorTestCases {
+		t.Run(name, func(t *testing.T) {
+			err := turtle.Unmarshal([]byte(tc.data), tc.target)
+			assert.ErrorIs(t, err, tc.expErr, "Unmarshal function should have returned a correct error")
+			assert.ErrorIs(t, err, turtle.ErrInvalidLiteral, "Unmarshal function should have returned a literal error")
+		})
+	}
+}
+
+func TestMarshalUnmarshalTyped(t *testing.T) {
+	triples := []tripleWithDuration{
+		{
+			Subject:   "http://example.org/book/Huckleberry_Finn",
+			Predicate: "http://example.org/relation/readingTime",
+			Object:    -(time.Hour + time.Nanosecond),
+		},
+		{
+			Subject:   "http://example.org/book/Tom_Sawyer",
+			Predicate: "http://example.org/relation/readingTime",
+			Object:    0,
+		},
+	}
+
+	b, err := turtle.Marshal(triples)
+	assert.NoError(t, err, "Marshal function should have returned no error")
+
+	var target []tripleWithDuration
+	err = turtle.Unmarshal(b, &target)
+	assert.NoError(t, err, "Unmarshal function should have returned no error")
+	assert.Equal(t, triples, target, "Unmarshal function should have returned the marshalled durations")
+}
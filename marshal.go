@@ -1,11 +1,12 @@
 package turtle
 
 import (
+	"fmt"
 	"reflect"
 
 	"errors"
 
-	"github.com/nvkp/turtle/graph"
+	"github.com/nvkp/turtle/rdf"
 )
 
 var (
@@ -28,29 +29,73 @@ var (
 // The fields of the structs passed to the function have to be annotated
 // by Golang tag `turtle` defining which of the fields correspond to
 // which part of the RDF triple (either "subject", "predicate" or "object").
+// A field tagged "graph" names the graph the triple belongs to, which
+// only the N-Quads format can write.
 //
 // The compact version of the Turtle format is used. The resulting Turtle
 // triples are sorted alphabetically first by subjects, then by predicates
 // and then by objects.
 func Marshal(v interface{}) ([]byte, error) {
-	return (&Config{}).Marshal(v)
+	return (&Config{ResolveURLs: true}).Marshal(v)
 }
 
-func marshal(g *graph.Graph, v reflect.Value) error {
+// acceptor consumes the triples extracted from the marshalled value.
+// It is implemented by both graph.Dataset and graph.Writer.
+type acceptor interface {
+	AcceptWithAnnotations(t [6]string) error
+	AcceptInGraph(name string, t [6]string) error
+}
+
+// marshaller extracts the triples from the marshalled values
+// and passes them to the acceptor.
+type marshaller struct {
+	g acceptor
+	// prefixes expand the prefixed names in the struct tags
+	prefixes map[string]string
+	verbatim bool
+	// visited holds the subjects of the already marshalled
+	// pointers to resources
+	visited map[visit]string
+	// active holds the subjects of the resources
+	// being marshalled at the moment
+	active     map[string]bool
+	blankNodes int
+}
+
+// visit identifies a pointer to a resource.
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+func (m *marshaller) marshal(v reflect.Value) error {
+	// the value can produce its own triples
+	if mm, ok := as[Marshaler](v); ok {
+		return m.marshalTriples(mm)
+	}
+
 	switch v.Kind() {
 	case reflect.Ptr:
+		// a resource can be referred to from the other resources as well
+		if !v.IsNil() && isResource(v.Type().Elem()) {
+			_, err := m.marshalNested(v)
+			return err
+		}
 		// if value is pointer marhal the pointed value
-		return marshal(g, v.Elem())
+		return m.marshal(v.Elem())
 	case reflect.Array, reflect.Slice:
 		// if value is iterable iterate over value and marshal each element
 		for i := 0; i < v.Len(); i++ {
-			if err := marshal(g, v.Index(i)); err != nil {
+			if err := m.marshal(v.Index(i)); err != nil {
 				return err
 			}
 		}
 	case reflect.Struct:
 		// if value is struct go look into the struct's fields
-		return marshalStruct(g, v)
+		if isResource(v.Type()) {
+			return m.marshalResource(v)
+		}
+		return m.marshalStruct(v)
 	default:
 		return ErrInvalidValueType
 	}
@@ -58,8 +103,10 @@ func marshal(g *graph.Graph, v reflect.Value) error {
 	return nil
 }
 
-func marshalStruct(g *graph.Graph, v reflect.Value) error {
+func (m *marshaller) marshalStruct(v reflect.Value) error {
 	var t [6]string
+	var term Term
+	var name, direction string
 
 	for i := 0; i < v.NumField(); i++ {
 		field := v.Field(i)
@@ -85,20 +132,45 @@ func marshalStruct(g *graph.Graph, v reflect.Value) error {
 			part = datatype
 		case "objecttype":
 			part = objecttype
+		case "graph", "direction":
+			part = -1
 		case "base", "prefix":
 			continue
 		}
 
 		var word string
+		var handled bool
+		// the object can be a typed value or a value producing its own term
+		if part == object {
+			var err error
+			if term, handled, err = marshalTerm(field); err != nil {
+				return err
+			}
+			word = term.Value
+		}
+		// the subject can be a quoted triple as well
+		if part == subject && isQuotedTriple(field.Type()) {
+			word, handled = marshalQuoted(field), true
+		}
 		// if field is string use its value
-		if field.Kind() == reflect.String {
+		if !handled && field.Kind() == reflect.String {
 			word = field.String()
 		}
 		// is field is pointer to string use the pointed value
-		if field.Kind() == reflect.Pointer && field.Type().Elem().Kind() == reflect.String && field.Elem().Kind() == reflect.String {
+		if !handled && field.Kind() == reflect.Pointer && field.Type().Elem().Kind() == reflect.String && field.Elem().Kind() == reflect.String {
 			word = field.Elem().String()
 		}
 
+		// the graph name and the direction are not parts of the triple
+		if part < 0 {
+			if tag == "graph" {
+				name = word
+			} else {
+				direction = word
+			}
+			continue
+		}
+
 		// fill the word to correct part of the triple
 		t[part] = word
 	}
@@ -115,8 +187,34 @@ func marshalStruct(g *graph.Graph, v reflect.Value) error {
 		return ErrNoObjectSpecified
 	}
 
-	// accept the extracted triple to graph
-	g.AcceptWithAnnotations(t)
+	// the annotations of the object's term are used unless they are set explicitly
+	if t[label] == "" && t[datatype] == "" {
+		t[label], t[datatype] = rdf.JoinLanguageTag(term.Label, term.Direction), term.Datatype
+	}
+	// the direction follows the language tag
+	if direction != "" {
+		lang, _ := rdf.SplitLanguageTag(t[label])
+		t[label] = rdf.JoinLanguageTag(lang, direction)
+	}
+	if err := checkDirection(t[label]); err != nil {
+		return err
+	}
+	if t[objecttype] == "" {
+		t[objecttype] = term.Type
+	}
+
+	// accept the extracted triple to its graph
+	return m.g.AcceptInGraph(name, t)
+}
+
+// checkDirection fails if the label has a base direction but it is not
+// a well-formed language tag, only that one can be followed by ltr or rtl.
+func checkDirection(label string) error {
+	if _, dir := rdf.SplitLanguageTag(label); dir != "" {
+		if _, err := rdf.NormalizeLanguageTag(label); err != nil {
+			return fmt.Errorf("%w: %s", err, label)
+		}
+	}
 
 	return nil
 }
@@ -1,11 +1,13 @@
 package turtle_test
 
 import (
+	"slices"
 	"strings"
 	"testing"
 
 	"github.com/nvkp/turtle"
 	"github.com/nvkp/turtle/assert"
+	"github.com/nvkp/turtle/graph"
 )
 
 type triple struct {
@@ -274,7 +276,8 @@ func TestMarshalOptions(t *testing.T) {
 	}
 
 	c := turtle.Config{
-		Base: "http://example.org",
+		ResolveURLs: true,
+		Base:        "http://example.org",
 		Prefixes: map[string]string{
 			"book": "http://example.org/books/",
 		},
@@ -285,13 +288,14 @@ func TestMarshalOptions(t *testing.T) {
 	assert.Equal(t, strings.TrimSpace(string(out)), strings.TrimSpace(`
 @base <http://example.org> .
 @prefix book: <http://example.org/books/> .
-</person/Mark_Twain> </relation/author> </books/Huckleberry_Finn> .
+</person/Mark_Twain> </relation/author> book:Huckleberry_Finn .
 `), "output was not equal")
 
 	// check for weird rdf-isms like using a blank anchor as a prefix
 
 	c = turtle.Config{
-		Base: "http://example.org",
+		ResolveURLs: true,
+		Base:        "http://example.org",
 		Prefixes: map[string]string{
 			"book": "http://example.org/books#",
 		},
@@ -308,7 +312,7 @@ func TestMarshalOptions(t *testing.T) {
 	assert.Equal(t, strings.TrimSpace(string(out)), strings.TrimSpace(`
 @base <http://example.org> .
 @prefix book: <http://example.org/books#> .
-</person/Mark_Twain> </relation/author> </books#Huckleberry_Finn> .
+</person/Mark_Twain> </relation/author> book:Huckleberry_Finn .
 `), "output was not equal")
 }
 
@@ -327,14 +331,206 @@ func TestMarshalPrefixBase(t *testing.T) {
 }
 
 func TestMarshalSubjectBaseURLTrailingSlash(t *testing.T) {
-	data := []byte("@base <http://example.org> .\n<.> </relation/author> </books/Huckleberry_Finn> .\n")
+	data := []byte("@base <http://example.org> .\n<> </relation/author> </books/Huckleberry_Finn> .\n")
 	expected := triple{
 		Subject:   "http://example.org",
 		Predicate: "http://example.org/relation/author",
 		Object:    "http://example.org/books/Huckleberry_Finn",
 	}
 
-	out, err := (&turtle.Config{Base: "http://example.org"}).Marshal(expected)
+	out, err := (&turtle.Config{ResolveURLs: true, Base: "http://example.org"}).Marshal(expected)
 	assert.NoError(t, err, "function Unmarshal should have returned no error")
 	assert.Equal(t, string(out), string(data), "function Unmarshal should have assigned correct values to the target triple")
 }
+
+func TestMarshalUnmarshalEscapes(t *testing.T) {
+	objects := []string{
+		`say "hello"`,
+		`it's`,
+		`both "quotes" and 'apostrophes'`,
+		`backslash \ and \n written out`,
+		`ending with a backslash \`,
+		"tab\tcarriage return\rbell\a",
+		"multiple\nlines ending with a quotation mark\"",
+		"multiple\nlines with ''' and \"\"\"",
+		"quotes \"\"\"\" and apostrophes ''''\nat the end ''",
+		"'''\n\"\"",
+		"Česká republika 🕷",
+	}
+
+	triples := make([]triple, 0, len(objects))
+	for _, o := range objects {
+		triples = append(triples, triple{
+			Subject:   "http://example.org/literals",
+			Predicate: "http://example.org/relation/value",
+			Object:    o,
+		})
+	}
+
+	// the marshaled objects are sorted
+	byObject := func(a, b triple) int { return strings.Compare(a.Object, b.Object) }
+	slices.SortFunc(triples, byObject)
+
+	for name, quotes := range map[string]graph.QuoteStyle{
+		"long":     graph.QuoteLong,
+		"double":   graph.QuoteDouble,
+		"ntriples": graph.QuoteNTriples,
+	} {
+		t.Run(name, func(t *testing.T) {
+			b, err := (&turtle.Config{Quotes: quotes}).Marshal(triples)
+			assert.NoError(t, err, "Marshal function should have returned no error")
+
+			actual := make([]triple, 0, len(objects))
+			err = turtle.Unmarshal(b, &actual)
+			assert.NoError(t, err, "Unmarshal function should have returned no error")
+
+			slices.SortFunc(actual, byObject)
+			assert.Equal(t, triples, actual, "triples should have survived the round trip unchanged")
+		})
+	}
+}
+
+func TestMarshalPrefixList(t *testing.T) {
+	c := turtle.Config{
+		ResolveURLs: true,
+		Prefixes: map[string]string{
+			"dc":   "http://purl.org/dc/elements/1.1/",
+			"book": "http://example.org/books/",
+		},
+		PrefixList: graph.PrefixList{
+			{Name: "person", IRI: "http://example.org/person/"},
+			{Name: "relation", IRI: "http://example.org/relation/"},
+		},
+	}
+
+	out, err := c.Marshal(triple{
+		Subject:   "person:Mark_Twain",
+		Predicate: "relation:author",
+		Object:    "http://example.org/books/Huckleberry_Finn",
+	})
+	assert.NoError(t, err, "no error was expected")
+	assert.Equal(t, `@prefix person: <http://example.org/person/> .
+@prefix relation: <http://example.org/relation/> .
+@prefix book: <http://example.org/books/> .
+@prefix dc: <http://purl.org/dc/elements/1.1/> .
+person:Mark_Twain relation:author book:Huckleberry_Finn .
+`, string(out), "prefixes of the list should have been written first in their order")
+}
+
+func TestMarshalUnmarshalCompacted(t *testing.T) {
+	triples := []tripleWithAnnotationValues{
+		{
+			Subject:    "http://example.org/people/Mark_Twain",
+			Predicate:  "http://xmlns.com/foaf/0.1/name",
+			Object:     "Mark Twain",
+			ObjectType: "literal",
+		},
+		{
+			Subject:    "http://example.org/people/Mark_Twain",
+			Predicate:  "http://example.org/relation/author",
+			Object:     "http://example.org/books/Adventures_(1884).v2",
+			ObjectType: "iri",
+		},
+	}
+
+	c := turtle.Config{
+		ResolveURLs: true,
+		Prefixes: map[string]string{
+			"ex":     "http://example.org/",
+			"people": "http://example.org/people/",
+			"foaf":   "http://xmlns.com/foaf/0.1/",
+			"dc":     "http://purl.org/dc/elements/1.1/",
+		},
+		OmitUnusedPrefixes: true,
+	}
+
+	out, err := c.Marshal(triples)
+	assert.NoError(t, err, "no error was expected")
+	assert.Equal(t, `@prefix ex: <http://example.org/> .
+@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+@prefix people: <http://example.org/people/> .
+people:Mark_Twain 
+	ex:relation\/author ex:books\/Adventures_\(1884\)\.v2 ;
+	foaf:name "Mark Twain" .
+`, string(out), "IRIs should have been compacted with the used prefixes only")
+
+	// the output has to conform to the grammar
+	actual := make([]tripleWithAnnotationValues, 0, len(triples))
+	err = (&turtle.Config{ResolveURLs: true, Strict: true}).Unmarshal(out, &actual)
+	assert.NoError(t, err, "no error was expected")
+
+	byPredicate := func(a, b tripleWithAnnotationValues) int { return strings.Compare(b.Predicate, a.Predicate) }
+	slices.SortFunc(actual, byPredicate)
+	assert.Equal(t, triples, actual, "compacted IRIs should have been read back unchanged")
+}
+
+func TestMarshalUnmarshalNamespaces(t *testing.T) {
+	triples := []tripleWithAnnotationValues{
+		{
+			Subject:    "urn:isbn:0451450523",
+			Predicate:  "http://ex.org/nsname",
+			Object:     "The Last Unicorn",
+			ObjectType: "literal",
+		},
+		{
+			Subject:    "urn:isbn:0451450523",
+			Predicate:  "http://ex.org/ns#author",
+			Object:     "http://ex.org/ns/Peter_S._Beagle",
+			ObjectType: "iri",
+		},
+	}
+
+	c := turtle.Config{
+		ResolveURLs: true,
+		Prefixes: map[string]string{
+			"isbn": "urn:isbn:",
+			"ex":   "http://ex.org/ns",
+		},
+	}
+
+	out, err := c.Marshal(triples)
+	assert.NoError(t, err, "no error was expected")
+	assert.Equal(t, `@prefix ex: <http://ex.org/ns> .
+@prefix isbn: <urn:isbn:> .
+isbn:0451450523 
+	ex:\#author ex:\/Peter_S\._Beagle ;
+	ex:name "The Last Unicorn" .
+`, string(out), "IRIs should have been compacted with the namespaces not ending with a slash")
+
+	// the local names are appended to the namespaces as they are
+	actual := make([]tripleWithAnnotationValues, 0, len(triples))
+	err = turtle.Unmarshal(out, &actual)
+	assert.NoError(t, err, "no error was expected")
+
+	byPredicate := func(a, b tripleWithAnnotationValues) int { return strings.Compare(b.Predicate, a.Predicate) }
+	slices.SortFunc(actual, byPredicate)
+	assert.Equal(t, triples, actual, "compacted IRIs should have been read back unchanged")
+}
+
+func TestMarshalVerbatim(t *testing.T) {
+	c := turtle.Config{
+		Base: "http://example.org/",
+		Prefixes: map[string]string{
+			"books": "https://amazon.com/",
+		},
+	}
+
+	out, err := c.Marshal([]triple{
+		{
+			Subject:   "http://example.org/person/Mark_Twain",
+			Predicate: "http://example.org/relation/author",
+			Object:    "books:Huckleberry_Finn",
+		},
+		{
+			Subject:   "/person/Mark_Twain",
+			Predicate: "/relation/author",
+			Object:    "https://amazon.com/Tom_Sawyer",
+		},
+	})
+	assert.NoError(t, err, "no error was expected")
+	assert.Equal(t, `@base <http://example.org/> .
+@prefix books: <https://amazon.com/> .
+</person/Mark_Twain> </relation/author> <https://amazon.com/Tom_Sawyer> .
+<http://example.org/person/Mark_Twain> <http://example.org/relation/author> books:Huckleberry_Finn .
+`, string(out), "IRIs should have been written as they are")
+}
@@ -0,0 +1,328 @@
+package turtle
+
+import (
+	"encoding"
+	"reflect"
+	"strings"
+
+	"github.com/nvkp/turtle/rdf"
+)
+
+// Triple is a single RDF triple as produced by a Marshaler
+// and consumed by an Unmarshaler. Its fields correspond to
+// the parts of the triple the struct tags describe.
+type Triple struct {
+	Subject    string
+	Predicate  string
+	Object     string
+	Label      string
+	Direction  string
+	Datatype   string
+	ObjectType string
+}
+
+func newTriple(t [6]string) Triple {
+	lang, direction := rdf.SplitLanguageTag(t[label])
+	return Triple{
+		Subject:    t[subject],
+		Predicate:  t[predicate],
+		Object:     t[object],
+		Label:      lang,
+		Direction:  direction,
+		Datatype:   t[datatype],
+		ObjectType: t[objecttype],
+	}
+}
+
+func (t Triple) parts() [6]string {
+	return [6]string{t.Subject, t.Predicate, t.Object, rdf.JoinLanguageTag(t.Label, t.Direction), t.Datatype, t.ObjectType}
+}
+
+// Term is a single object of a triple. Its type is either TypeIRI,
+// TypeLiteral or TypeTriple, when empty it is determined by the value.
+// The direction is the base direction of a literal with a language tag,
+// either rdf.DirectionLTR or rdf.DirectionRTL.
+type Term struct {
+	Value     string
+	Label     string
+	Direction string
+	Datatype  string
+	Type      string
+}
+
+// Marshaler is implemented by types that produce their own triples
+// instead of having their fields read by Marshal.
+type Marshaler interface {
+	MarshalTurtle() ([]Triple, error)
+}
+
+// Unmarshaler is implemented by types that consume their own triples
+// instead of having their fields filled by Unmarshal. The value passed
+// to Unmarshal gets all the triples of the document, an element of
+// a slice gets the triples of a single subject.
+type Unmarshaler interface {
+	UnmarshalTurtle(triples []Triple) error
+}
+
+// TermMarshaler is implemented by types that produce their own object
+// term when used as the object of a triple.
+type TermMarshaler interface {
+	MarshalTurtleTerm() (Term, error)
+}
+
+// TermUnmarshaler is implemented by types that consume their own object
+// term when used as the object of a triple.
+type TermUnmarshaler interface {
+	UnmarshalTurtleTerm(term Term) error
+}
+
+var (
+	unmarshalerType     = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
+	termUnmarshalerType = reflect.TypeOf((*TermUnmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	quotedTripleType    = reflect.TypeOf(rdf.QuotedTriple{})
+)
+
+// as returns the value or the pointer to it as the interface
+// if it implements it. Nil pointers are never returned.
+func as[T any](v reflect.Value) (T, bool) {
+	var zero T
+	if !v.IsValid() || v.Kind() == reflect.Pointer && v.IsNil() {
+		return zero, false
+	}
+
+	if v.CanInterface() {
+		if i, ok := v.Interface().(T); ok {
+			return i, true
+		}
+	}
+
+	if v.CanAddr() && v.Addr().CanInterface() {
+		if i, ok := v.Addr().Interface().(T); ok {
+			return i, true
+		}
+	}
+
+	return zero, false
+}
+
+// implements reports whether the type or the pointer to it implements
+// the interface, so that the settable value of the type can be passed
+// to unmarshaler.
+func implements(t reflect.Type, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PointerTo(t).Implements(iface)
+}
+
+// unmarshaler returns the settable value as the interface.
+// The nil pointer is allocated first.
+func unmarshaler[T any](v reflect.Value, iface reflect.Type) (T, bool) {
+	var zero T
+	if v.Kind() == reflect.Pointer && v.Type().Implements(iface) {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return v.Interface().(T), true
+	}
+
+	if v.CanAddr() && reflect.PointerTo(v.Type()).Implements(iface) {
+		return v.Addr().Interface().(T), true
+	}
+
+	if v.Type().Implements(iface) {
+		return v.Interface().(T), true
+	}
+
+	return zero, false
+}
+
+// marshalTerm returns the object term of the value. The TermMarshaler
+// implementation is used first, then the quoted triples are written
+// in their N-Triples form, then the typed values are written
+// as literals with their datatype and then the text of the
+// encoding.TextMarshaler is written as a plain literal. It reports
+// false if the value is none of those.
+func marshalTerm(v reflect.Value) (Term, bool, error) {
+	if m, ok := as[TermMarshaler](v); ok {
+		term, err := m.MarshalTurtleTerm()
+		return term, true, err
+	}
+
+	if isQuotedTriple(v.Type()) {
+		return Term{Value: marshalQuoted(v), Type: TypeTriple}, true, nil
+	}
+
+	if isTypedLiteral(v.Type()) {
+		value, datatype := marshalLiteral(v)
+		return Term{Value: value, Datatype: datatype, Type: TypeLiteral}, true, nil
+	}
+
+	if m, ok := as[encoding.TextMarshaler](v); ok {
+		text, err := m.MarshalText()
+		return Term{Value: string(text), Type: TypeLiteral}, true, err
+	}
+
+	return Term{}, false, nil
+}
+
+// unmarshalTerm sets the value from the object term the same
+// way marshalTerm gets it. It reports false if the value
+// is none of the handled ones.
+func unmarshalTerm(v reflect.Value, term Term) (bool, error) {
+	if u, ok := unmarshaler[TermUnmarshaler](v, termUnmarshalerType); ok {
+		return true, u.UnmarshalTurtleTerm(term)
+	}
+
+	if isQuotedTriple(v.Type()) {
+		return true, unmarshalQuoted(v, term.Value)
+	}
+
+	if isTypedLiteral(v.Type()) {
+		return true, unmarshalLiteral(v, term.Value, term.Datatype)
+	}
+
+	if u, ok := unmarshaler[encoding.TextUnmarshaler](v, textUnmarshalerType); ok {
+		if err := u.UnmarshalText([]byte(term.Value)); err != nil {
+			return true, &LiteralError{Value: term.Value, Datatype: term.Datatype, Type: v.Type(), Err: err}
+		}
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// isQuotedTriple reports whether the type is a quoted triple
+// or a pointer to it.
+func isQuotedTriple(t reflect.Type) bool {
+	return t == quotedTripleType || t.Kind() == reflect.Pointer && t.Elem() == quotedTripleType
+}
+
+// marshalQuoted returns the quoted triple in its N-Triples form,
+// an empty string for a nil pointer or an empty quoted triple.
+func marshalQuoted(v reflect.Value) string {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+
+	q := v.Interface().(rdf.QuotedTriple)
+	if q.Subject == nil || q.Predicate == nil || q.Object == nil {
+		return ""
+	}
+
+	return q.String()
+}
+
+// unmarshalQuoted sets the quoted triple parsed from its N-Triples
+// form. Values of other terms are left untouched.
+func unmarshalQuoted(v reflect.Value, value string) error {
+	if !strings.HasPrefix(value, "<<") {
+		return nil
+	}
+
+	t, err := rdf.ParseTerm(value)
+	if err != nil {
+		return err
+	}
+
+	q := reflect.ValueOf(t)
+	if q.Type() != quotedTripleType {
+		return nil
+	}
+
+	if v.Kind() == reflect.Pointer {
+		ptr := reflect.New(quotedTripleType)
+		ptr.Elem().Set(q)
+		q = ptr
+	}
+	v.Set(q)
+
+	return nil
+}
+
+func objectTerm(t [6]string) Term {
+	lang, direction := rdf.SplitLanguageTag(t[label])
+	return Term{Value: t[object], Label: lang, Direction: direction, Datatype: t[datatype], Type: t[objecttype]}
+}
+
+// marshalTriples accepts the triples produced by the Marshaler.
+func (m *marshaller) marshalTriples(mm Marshaler) error {
+	triples, err := mm.MarshalTurtle()
+	if err != nil {
+		return err
+	}
+
+	for _, t := range triples {
+		parts := t.parts()
+		if err := checkDirection(parts[label]); err != nil {
+			return err
+		}
+
+		if err := m.g.AcceptWithAnnotations(parts); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// unmarshalTriples passes all the triples to the Unmarshaler.
+func unmarshalTriples(s source, u Unmarshaler) error {
+	var triples []Triple
+	for s.Next() {
+		t := s.TripleWithAnnotations()
+		// skip the pragmas
+		if t[subject] != "" {
+			triples = append(triples, newTriple(t))
+		}
+	}
+
+	if err := s.Err(); err != nil {
+		return err
+	}
+
+	return u.UnmarshalTurtle(triples)
+}
+
+// unmarshalTripleGroups appends an element to the slice for every
+// subject and passes the subject's triples to its Unmarshaler.
+func unmarshalTripleGroups(s source, v reflect.Value) error {
+	rs := newResources(s.Prefixes())
+	for s.Next() {
+		t := s.TripleWithAnnotations()
+		// skip the pragmas
+		if t[subject] != "" {
+			rs.add(t)
+		}
+	}
+
+	if err := s.Err(); err != nil {
+		return err
+	}
+
+	for _, r := range rs.list {
+		item := reflect.New(v.Type().Elem()).Elem()
+		if err := unmarshalGroup(item, r.triples); err != nil {
+			return err
+		}
+		v.Set(reflect.Append(v, item))
+	}
+
+	return nil
+}
+
+// unmarshalGroup passes the triples to the value's Unmarshaler.
+func unmarshalGroup(v reflect.Value, group [][6]string) error {
+	u, ok := unmarshaler[Unmarshaler](v, unmarshalerType)
+	if !ok {
+		return ErrInvalidValueType
+	}
+
+	triples := make([]Triple, 0, len(group))
+	for _, t := range group {
+		triples = append(triples, newTriple(t))
+	}
+
+	return u.UnmarshalTurtle(triples)
+}
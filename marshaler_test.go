@@ -0,0 +1,306 @@
+package turtle_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/nvkp/turtle"
+	"github.com/nvkp/turtle/assert"
+	"github.com/nvkp/turtle/rdf"
+)
+
+const statusNamespace = "http://example.org/status/"
+
+var errUnknownStatus = errors.New("unknown status")
+
+type status int
+
+const (
+	statusDraft status = iota
+	statusPublished
+)
+
+var statusNames = []string{"draft", "published"}
+
+func (s status) MarshalTurtleTerm() (turtle.Term, error) {
+	if int(s) >= len(statusNames) {
+		return turtle.Term{}, errUnknownStatus
+	}
+
+	return turtle.Term{Value: statusNamespace + statusNames[s], Type: turtle.TypeIRI}, nil
+}
+
+func (s *status) UnmarshalTurtleTerm(term turtle.Term) error {
+	for i, name := range statusNames {
+		if term.Value == statusNamespace+name {
+			*s = status(i)
+			return nil
+		}
+	}
+
+	return errUnknownStatus
+}
+
+type money struct {
+	Amount   int
+	Currency string
+}
+
+func (m money) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%d.%02d %s", m.Amount/100, m.Amount%100, m.Currency)), nil
+}
+
+func (m *money) UnmarshalText(text []byte) error {
+	var whole, cents int
+	_, err := fmt.Sscanf(string(text), "%d.%d %s", &whole, &cents, &m.Currency)
+	m.Amount = whole*100 + cents
+	return err
+}
+
+type book struct {
+	ID     string
+	Title  string
+	Status status
+}
+
+func (b book) MarshalTurtle() ([]turtle.Triple, error) {
+	return []turtle.Triple{
+		{Subject: b.ID, Predicate: "http://purl.org/dc/terms/title", Object: b.Title, Label: "en", ObjectType: turtle.TypeLiteral},
+		{Subject: b.ID, Predicate: "http://example.org/status", Object: statusNamespace + statusNames[b.Status], ObjectType: turtle.TypeIRI},
+	}, nil
+}
+
+func (b *book) UnmarshalTurtle(triples []turtle.Triple) error {
+	for _, t := range triples {
+		b.ID = t.Subject
+		switch t.Predicate {
+		case "http://purl.org/dc/terms/title":
+			b.Title = t.Object
+		case "http://example.org/status":
+			if err := b.Status.UnmarshalTurtleTerm(turtle.Term{Value: t.Object}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+type tripleWithStatus struct {
+	Subject   string `turtle:"subject"`
+	Predicate string `turtle:"predicate"`
+	Object    status `turtle:"object"`
+}
+
+type product struct {
+	ID     string  `turtle:"@id"`
+	Status status  `turtle:"ex:status"`
+	Price  money   `turtle:"ex:price"`
+	Prices []money `turtle:"ex:formerPrice"`
+}
+
+type certainty struct {
+	Statement rdf.QuotedTriple `turtle:"subject"`
+	Predicate string           `turtle:"predicate"`
+	Certainty float64          `turtle:"object"`
+}
+
+type claim struct {
+	ID     string            `turtle:"@id"`
+	Claims *rdf.QuotedTriple `turtle:"ex:claims"`
+}
+
+func TestMarshalUnmarshalQuotedTriple(t *testing.T) {
+	statement := rdf.QuotedTriple{
+		Subject:   rdf.IRI("http://example.org/alice"),
+		Predicate: rdf.IRI("http://example.org/knows"),
+		Object:    rdf.Literal{Value: "Bob", Lang: "en"},
+	}
+	triple := certainty{Statement: statement, Predicate: "http://example.org/certainty", Certainty: 0.5}
+	expected := `<< <http://example.org/alice> <http://example.org/knows> "Bob"@en >> <http://example.org/certainty> 5.0E-1 .
+`
+
+	b, err := turtle.Marshal(triple)
+	assert.NoError(t, err, "Marshal function should have returned no error")
+	assert.Equal(t, expected, string(b), "Marshal function should have written the quoted triple as the subject")
+
+	var target certainty
+	err = turtle.Unmarshal(b, &target)
+	assert.NoError(t, err, "Unmarshal function should have returned no error")
+	assert.Equal(t, triple, target, "Unmarshal function should have read the quoted triple of the subject")
+
+	c := claim{ID: "http://example.org/bob", Claims: &statement}
+	expected = `@prefix ex: <http://example.org/> .
+@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+ex:bob ex:claims << ex:alice ex:knows "Bob"@en >> .
+`
+
+	b, err = exConfig.Marshal(c)
+	assert.NoError(t, err, "Marshal function should have returned no error")
+	assert.Equal(t, expected, string(b), "Marshal function should have written the quoted triple as the object")
+
+	var targets []claim
+	err = exConfig.Unmarshal(b, &targets)
+	assert.NoError(t, err, "Unmarshal function should have returned no error")
+	assert.Equal(t, []claim{c}, targets, "Unmarshal function should have read the quoted triple of the object")
+}
+
+func TestMarshalTermMarshaler(t *testing.T) {
+	triple := tripleWithStatus{
+		Subject:   "http://example.org/book/Huckleberry_Finn",
+		Predicate: "http://example.org/status",
+		Object:    statusPublished,
+	}
+	expected := `<http://example.org/book/Huckleberry_Finn> <http://example.org/status> <http://example.org/status/published> .
+`
+
+	b, err := turtle.Marshal(triple)
+	assert.NoError(t, err, "Marshal function should have returned no error")
+	assert.Equal(t, expected, string(b), "Marshal function should have written the term of the object")
+
+	triple.Object = status(42)
+	_, err = turtle.Marshal(triple)
+	assert.ErrorIs(t, err, errUnknownStatus, "Marshal function should have returned the term's error")
+}
+
+// greeting is a text in the given language and base direction.
+type greeting struct {
+	Text      string
+	Lang      string
+	Direction string
+}
+
+func (g greeting) MarshalTurtleTerm() (turtle.Term, error) {
+	return turtle.Term{Value: g.Text, Label: g.Lang, Direction: g.Direction, Type: turtle.TypeLiteral}, nil
+}
+
+type welcome struct {
+	ID       string   `turtle:"@id"`
+	Greeting greeting `turtle:"http://example.org/greeting"`
+}
+
+type greetings []turtle.Triple
+
+func (g greetings) MarshalTurtle() ([]turtle.Triple, error) {
+	return g, nil
+}
+
+func TestMarshalDirection(t *testing.T) {
+	w := welcome{ID: "http://example.org/w", Greeting: greeting{Text: "مرحبا", Lang: "ar", Direction: rdf.DirectionRTL}}
+	expected := `<http://example.org/w> <http://example.org/greeting> "مرحبا"@ar--rtl .
+`
+
+	b, err := turtle.Marshal(w)
+	assert.NoError(t, err, "Marshal function should have returned no error")
+	assert.Equal(t, expected, string(b), "Marshal function should have written the direction of the term")
+
+	w.Greeting.Lang = "123abc"
+	_, err = turtle.Marshal(w)
+	assert.ErrorIs(t, err, rdf.ErrInvalidLanguageTag, "Marshal function should have rejected the language tag of the term")
+
+	g := greetings{{Subject: "http://example.org/w", Predicate: "http://example.org/greeting", Object: "مرحبا", Label: "ar", Direction: "up", ObjectType: turtle.TypeLiteral}}
+	_, err = turtle.Marshal(g)
+	assert.ErrorIs(t, err, rdf.ErrInvalidLanguageTag, "Marshal function should have rejected the direction of the triple")
+}
+
+func TestUnmarshalTermUnmarshaler(t *testing.T) {
+	var target tripleWithStatus
+	err := turtle.Unmarshal([]byte(`<http://example.org/book/Huckleberry_Finn> <http://example.org/status> <http://example.org/status/published> .`), &target)
+	assert.NoError(t, err, "Unmarshal function should have returned no error")
+	assert.Equal(t, statusPublished, target.Object, "Unmarshal function should have read the term of the object")
+
+	err = turtle.Unmarshal([]byte(`<http://example.org/book/Huckleberry_Finn> <http://example.org/status> <http://example.org/status/lost> .`), &target)
+	assert.ErrorIs(t, err, errUnknownStatus, "Unmarshal function should have returned the term's error")
+}
+
+func TestMarshalUnmarshalTextMarshaler(t *testing.T) {
+	products := []product{
+		{
+			ID:     "http://example.org/product/1",
+			Status: statusPublished,
+			Price:  money{Amount: 1250, Currency: "EUR"},
+			Prices: []money{{Amount: 1500, Currency: "EUR"}},
+		},
+	}
+	expected := `@prefix ex: <http://example.org/vocab/> .
+<http://example.org/product/1> 
+	ex:formerPrice "15.00 EUR" ;
+	ex:price "12.50 EUR" ;
+	ex:status <http://example.org/status/published> .
+`
+
+	config := turtle.Config{
+		ResolveURLs: true,
+		Prefixes:    map[string]string{"ex": "http://example.org/vocab/"},
+	}
+
+	b, err := config.Marshal(products)
+	assert.NoError(t, err, "Marshal function should have returned no error")
+	assert.Equal(t, expected, string(b), "Marshal function should have written the text of the values")
+
+	var target []product
+	err = config.Unmarshal(b, &target)
+	assert.NoError(t, err, "Unmarshal function should have returned no error")
+	assert.Equal(t, products, target, "Unmarshal function should have read the text of the values")
+
+	err = config.Unmarshal([]byte(`<http://example.org/product/1> <http://example.org/vocab/price> "cheap" .`), &target)
+	assert.ErrorIs(t, err, turtle.ErrInvalidLiteral, "Unmarshal function should have returned a literal error")
+}
+
+func TestMarshalMarshaler(t *testing.T) {
+	books := []book{
+		{ID: "http://example.org/book/Tom_Sawyer", Title: "Tom Sawyer", Status: statusDraft},
+		{ID: "http://example.org/book/Huckleberry_Finn", Title: "Huckleberry Finn", Status: statusPublished},
+	}
+	expected := `<http://example.org/book/Huckleberry_Finn> 
+	<http://example.org/status> <http://example.org/status/published> ;
+	<http://purl.org/dc/terms/title> "Huckleberry Finn"@en .
+<http://example.org/book/Tom_Sawyer> 
+	<http://example.org/status> <http://example.org/status/draft> ;
+	<http://purl.org/dc/terms/title> "Tom Sawyer"@en .
+`
+
+	b, err := turtle.Marshal(books)
+	assert.NoError(t, err, "Marshal function should have returned no error")
+	assert.Equal(t, expected, string(b), "Marshal function should have written the triples of the values")
+
+	var target []book
+	err = turtle.Unmarshal(b, &target)
+	assert.NoError(t, err, "Unmarshal function should have returned no error")
+	assert.Equal(t, []book{books[1], books[0]}, target, "Unmarshal function should have passed the triples of every subject")
+
+	d := turtle.NewDecoder(strings.NewReader(string(b)))
+	var decoded []book
+	for d.More() {
+		var target book
+		err := d.Decode(&target)
+		assert.NoError(t, err, "method Decode should have returned no error")
+		decoded = append(decoded, target)
+	}
+	assert.Equal(t, target, decoded, "decoder should have passed the triples of every subject")
+}
+
+type catalogue struct {
+	triples []turtle.Triple
+}
+
+func (c *catalogue) UnmarshalTurtle(triples []turtle.Triple) error {
+	c.triples = triples
+	return nil
+}
+
+func TestUnmarshalUnmarshaler(t *testing.T) {
+	data := []byte(`@prefix ex: <http://example.org/> .
+ex:a ex:b "c"@en, ex:d .`)
+	expected := []turtle.Triple{
+		{Subject: "http://example.org/a", Predicate: "http://example.org/b", Object: "c", Label: "en", ObjectType: turtle.TypeLiteral},
+		{Subject: "http://example.org/a", Predicate: "http://example.org/b", Object: "http://example.org/d", ObjectType: turtle.TypeIRI},
+	}
+
+	var target catalogue
+	err := turtle.Unmarshal(data, &target)
+	assert.NoError(t, err, "Unmarshal function should have returned no error")
+	assert.Equal(t, expected, target.triples, "Unmarshal function should have passed all the triples")
+}
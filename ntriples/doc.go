@@ -0,0 +1,7 @@
+// Package ntriples implements a fast reader of N-Triples data. Unlike
+// the scanner, which handles N-Triples as a subset of Turtle, the reader
+// reads the data line by line without keeping any state between the lines
+// and accepts only the data following the N-Triples grammar exactly.
+// The same way it reads N-Quads data, where every triple can be
+// followed by the name of the graph it belongs to.
+package ntriples
@@ -0,0 +1,273 @@
+package ntriples
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/nvkp/turtle/rdf"
+)
+
+// productions of the N-Triples grammar as defined by https://www.w3.org/TR/n-triples/#n-triples-grammar
+const (
+	pnCharsU = rdf.PNCharsBase + `_:`
+	pnChars  = pnCharsU + `\-0-9\x{00B7}\x{0300}-\x{036F}\x{203F}-\x{2040}`
+)
+
+var (
+	regexBlankNodeLabel = regexp.MustCompile(`^_:[` + pnCharsU + `0-9](?:[` + pnChars + `.]*[` + pnChars + `])?`)
+	regexLangTag        = regexp.MustCompile(`^@[a-zA-Z]+(?:-[a-zA-Z0-9]+)*(?:--[a-zA-Z]+)?`)
+	regexScheme         = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9+.\-]*:`)
+)
+
+// lineError describes what was expected at a byte of a malformed line.
+type lineError struct {
+	at       int
+	expected string
+}
+
+// parser reads the terms of a single line one after another.
+type parser struct {
+	line []byte
+	i    int
+}
+
+// parseLine returns the triple of the line together with its optional
+// graph name if quads are read. It reports false if the line is empty
+// or holds only a comment.
+func parseLine(line []byte, quads bool) (rdf.Quad, bool, *lineError) {
+	p := &parser{line: line}
+	p.skipSpace()
+	if p.end() {
+		return rdf.Quad{}, false, nil
+	}
+
+	var t rdf.Quad
+	var err *lineError
+
+	if t.Subject, err = p.resource("IRI or blank node"); err != nil {
+		return t, false, err
+	}
+	p.skipSpace()
+
+	if t.Predicate, err = p.iri("IRI"); err != nil {
+		return t, false, err
+	}
+	p.skipSpace()
+
+	if t.Object, err = p.object(); err != nil {
+		return t, false, err
+	}
+	p.skipSpace()
+
+	if quads && p.i < len(p.line) && p.line[p.i] != '.' {
+		if t.Graph, err = p.resource("IRI, blank node or \".\""); err != nil {
+			return t, false, err
+		}
+		p.skipSpace()
+	}
+
+	if err = p.terminator(); err != nil {
+		return t, false, err
+	}
+
+	return t, true, nil
+}
+
+// terminator reads the full stop ending the triple
+// and an optional comment following it.
+func (p *parser) terminator() *lineError {
+	if p.i == len(p.line) || p.line[p.i] != '.' {
+		return p.fail(`"."`)
+	}
+	p.i++
+
+	p.skipSpace()
+	if !p.end() {
+		return p.fail("end of line")
+	}
+
+	return nil
+}
+
+func (p *parser) skipSpace() {
+	for p.i < len(p.line) && (p.line[p.i] == ' ' || p.line[p.i] == '\t') {
+		p.i++
+	}
+}
+
+// end reports whether the rest of the line is empty or a comment.
+func (p *parser) end() bool {
+	return p.i == len(p.line) || p.line[p.i] == '#'
+}
+
+func (p *parser) fail(expected string) *lineError {
+	return &lineError{at: p.i, expected: expected}
+}
+
+// resource reads an IRI or a blank node.
+func (p *parser) resource(expected string) (rdf.Term, *lineError) {
+	if bytes.HasPrefix(p.line[p.i:], []byte("_:")) {
+		return p.blankNode()
+	}
+
+	return p.iri(expected)
+}
+
+func (p *parser) object() (rdf.Term, *lineError) {
+	if p.i < len(p.line) && p.line[p.i] == '"' {
+		return p.literal()
+	}
+
+	return p.resource("IRI, blank node or literal")
+}
+
+func (p *parser) blankNode() (rdf.Term, *lineError) {
+	label := regexBlankNodeLabel.Find(p.line[p.i:])
+	if label == nil {
+		return nil, p.fail("blank node label")
+	}
+
+	p.i += len(label)
+	return rdf.BlankNode(label[2:]), nil
+}
+
+// iri reads an absolute IRI enclosed in angle brackets
+// and replaces its escape sequences.
+func (p *parser) iri(expected string) (rdf.IRI, *lineError) {
+	if p.i == len(p.line) || p.line[p.i] != '<' {
+		return "", p.fail(expected)
+	}
+
+	start := p.i
+	end := bytes.IndexByte(p.line[start:], '>')
+	if end == -1 {
+		return "", p.fail("IRI closed by >")
+	}
+	end += start
+
+	var b strings.Builder
+	b.Grow(end - start)
+
+	for i := start + 1; i < end; i++ {
+		c := p.line[i]
+		switch {
+		case c == '\\':
+			r, n, ok := unescapeUnicode(p.line[i+1 : end])
+			if !ok {
+				p.i = i
+				return "", p.fail("\\u or \\U escape sequence")
+			}
+			b.WriteRune(r)
+			i += n
+		case c <= ' ' || bytes.IndexByte([]byte(`<"{}|^`+"`"), c) != -1:
+			p.i = i
+			return "", p.fail("IRI character")
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	iri := b.String()
+	if !regexScheme.MatchString(iri) {
+		return "", p.fail("absolute IRI")
+	}
+
+	p.i = end + 1
+	return rdf.IRI(iri), nil
+}
+
+// literal reads a literal enclosed in quotation marks, replaces
+// its escape sequences and reads its language tag or datatype.
+func (p *parser) literal() (rdf.Term, *lineError) {
+	var b strings.Builder
+
+	i := p.i + 1
+	for {
+		if i >= len(p.line) {
+			p.i = i
+			return nil, p.fail(`literal closed by "`)
+		}
+
+		c := p.line[i]
+		if c == '"' {
+			break
+		}
+
+		if c != '\\' {
+			b.WriteByte(c)
+			i++
+			continue
+		}
+
+		if i+1 < len(p.line) {
+			if e, ok := rdf.UnescapeChar(p.line[i+1]); ok {
+				b.WriteByte(e)
+				i += 2
+				continue
+			}
+		}
+
+		r, n, ok := unescapeUnicode(p.line[i+1:])
+		if !ok {
+			p.i = i
+			return nil, p.fail("escape sequence")
+		}
+		b.WriteRune(r)
+		i += n + 1
+	}
+	p.i = i + 1
+
+	l := rdf.Literal{Value: b.String()}
+	switch {
+	case bytes.HasPrefix(p.line[p.i:], []byte("@")):
+		tag := regexLangTag.Find(p.line[p.i:])
+		if tag == nil {
+			return nil, p.fail("language tag")
+		}
+		normalized, err := rdf.NormalizeLanguageTag(string(tag[1:]))
+		if err != nil {
+			return nil, p.fail("language tag")
+		}
+		l.Lang, l.Direction = rdf.SplitLanguageTag(normalized)
+		p.i += len(tag)
+	case bytes.HasPrefix(p.line[p.i:], []byte("^^")):
+		p.i += 2
+		datatype, err := p.iri("datatype IRI")
+		if err != nil {
+			return nil, err
+		}
+		l.Datatype = datatype
+	}
+
+	return l, nil
+}
+
+// unescapeUnicode decodes the code point of an escape sequence in the form
+// uXXXX or UXXXXXXXX, returning the number of bytes it occupies.
+func unescapeUnicode(data []byte) (rune, int, bool) {
+	var n int
+	switch {
+	case len(data) == 0:
+		return 0, 0, false
+	case data[0] == 'u':
+		n = 5
+	case data[0] == 'U':
+		n = 9
+	default:
+		return 0, 0, false
+	}
+
+	if len(data) < n {
+		return 0, 0, false
+	}
+
+	code, err := strconv.ParseUint(string(data[1:n]), 16, 32)
+	if err != nil || !utf8.ValidRune(rune(code)) {
+		return 0, 0, false
+	}
+
+	return rune(code), n, true
+}
@@ -0,0 +1,170 @@
+package ntriples
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"unicode/utf8"
+
+	"github.com/nvkp/turtle/rdf"
+	"github.com/nvkp/turtle/scanner"
+)
+
+// maxLineSize limits the size of a single line
+// the reader is able to hold in its buffer.
+const maxLineSize = 64 * 1024 * 1024
+
+// Reader reads N-Triples data triple by triple. Every line holds
+// a single triple, is empty or holds only a comment. A Reader
+// returned by NewQuadReader reads N-Quads data instead, where
+// the triple can be followed by the name of its graph.
+type Reader struct {
+	s     *bufio.Scanner
+	t     rdf.Quad
+	quads bool
+	// line is the position of the start of the current line
+	line scanner.Position
+	// read is the number of bytes of all the lines read so far
+	read int
+	err  error
+}
+
+// NewReader returns a new ntriples.Reader reading N-Triples from r.
+func NewReader(r io.Reader) *Reader {
+	return newReader(r, false)
+}
+
+// NewQuadReader returns a new ntriples.Reader reading N-Quads from r.
+func NewQuadReader(r io.Reader) *Reader {
+	return newReader(r, true)
+}
+
+func newReader(r io.Reader, quads bool) *Reader {
+	reader := &Reader{
+		quads: quads,
+		s:     bufio.NewScanner(r),
+		line:  scanner.Position{Column: 1},
+	}
+
+	reader.s.Buffer(nil, maxLineSize)
+	reader.s.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		advance, token, err := bufio.ScanLines(data, atEOF)
+		if token != nil {
+			reader.line.Offset = reader.read
+			reader.line.Line++
+			reader.read += advance
+		}
+		return advance, token, err
+	})
+
+	return reader
+}
+
+// Next reads the next triple, when succesful it stores it and returns
+// true. At the end of the data or at the first malformed line it returns
+// false. Another calls to Next would also return false.
+func (r *Reader) Next() bool {
+	r.t = rdf.Quad{}
+	if r.err != nil {
+		return false
+	}
+
+	for r.s.Scan() {
+		line := r.s.Bytes()
+
+		t, ok, err := parseLine(line, r.quads)
+		if err != nil {
+			r.err = r.syntaxError(line, err)
+			return false
+		}
+
+		if ok {
+			r.t = t
+			return true
+		}
+	}
+
+	r.err = r.s.Err()
+	return false
+}
+
+// Statement returns the current triple. The triple is empty if there is none.
+func (r *Reader) Statement() rdf.Triple {
+	return r.t.Triple()
+}
+
+// Quad returns the current triple together with the name of its graph,
+// which is nil for the triples of the default graph.
+func (r *Reader) Quad() rdf.Quad {
+	return r.t
+}
+
+// Graph returns the name of the current triple's graph as the string
+// graph.Dataset uses for it, an empty string for the default graph.
+func (r *Reader) Graph() string {
+	return value(r.t.Graph)
+}
+
+// Triple returns the current triple as the subject, predicate and object.
+func (r *Reader) Triple() [3]string {
+	t := r.TripleWithAnnotations()
+	return [3]string{t[0], t[1], t[2]}
+}
+
+// TripleWithAnnotations returns the current triple with label
+// and datatype of the object literal and the type of the object
+// in the same form as scanner.Scanner does.
+func (r *Reader) TripleWithAnnotations() [6]string {
+	if r.t.Subject == nil {
+		return [6]string{}
+	}
+
+	t := [6]string{value(r.t.Subject), value(r.t.Predicate), value(r.t.Object), "", "", "iri"}
+	if l, ok := r.t.Object.(rdf.Literal); ok {
+		t[3], t[4], t[5] = rdf.JoinLanguageTag(l.Lang, l.Direction), string(l.Datatype), "literal"
+	}
+
+	return t
+}
+
+// Err returns the first error that occurred while reading the data.
+// A malformed line results in a *scanner.SyntaxError.
+func (r *Reader) Err() error {
+	return r.err
+}
+
+func (r *Reader) syntaxError(line []byte, err *lineError) error {
+	token := line[err.at:]
+	switch i := bytes.IndexAny(token, " \t"); {
+	case len(token) == 0:
+		token = []byte("\n")
+	case i == 0:
+		token = token[:1]
+	case i != -1:
+		token = token[:i]
+	}
+
+	position := r.line
+	position.Offset += err.at
+	position.Column += utf8.RuneCount(line[:err.at])
+
+	return &scanner.SyntaxError{
+		Position: position,
+		Token:    string(token),
+		Expected: err.expected,
+	}
+}
+
+// value returns the term as the string the scanner uses for it.
+func value(t rdf.Term) string {
+	switch t := t.(type) {
+	case rdf.IRI:
+		return string(t)
+	case rdf.BlankNode:
+		return t.String()
+	case rdf.Literal:
+		return t.Value
+	}
+
+	return ""
+}
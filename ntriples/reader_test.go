@@ -0,0 +1,235 @@
+package ntriples_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nvkp/turtle/assert"
+	"github.com/nvkp/turtle/ntriples"
+	"github.com/nvkp/turtle/rdf"
+	"github.com/nvkp/turtle/scanner"
+)
+
+var readerTestCases = map[string]struct {
+	data     string
+	expected []rdf.Triple
+}{
+	"iris": {
+		data: "<http://example.org/a> <http://example.org/b> <http://example.org/c> .\n",
+		expected: []rdf.Triple{
+			{Subject: rdf.IRI("http://example.org/a"), Predicate: rdf.IRI("http://example.org/b"), Object: rdf.IRI("http://example.org/c")},
+		},
+	},
+	"blank_nodes": {
+		data: "_:a <http://example.org/b> _:c.d .\n_:e.f <http://example.org/b> _:g.",
+		expected: []rdf.Triple{
+			{Subject: rdf.BlankNode("a"), Predicate: rdf.IRI("http://example.org/b"), Object: rdf.BlankNode("c.d")},
+			{Subject: rdf.BlankNode("e.f"), Predicate: rdf.IRI("http://example.org/b"), Object: rdf.BlankNode("g")},
+		},
+	},
+	"literals": {
+		data: `<http://example.org/a> <http://example.org/b> "plain" .
+<http://example.org/a> <http://example.org/b> "English"@en-US .
+<http://example.org/a> <http://example.org/b> "عربي"@AR--rtl .
+<http://example.org/a> <http://example.org/b> "42"^^<http://www.w3.org/2001/XMLSchema#integer> .
+`,
+		expected: []rdf.Triple{
+			{Subject: rdf.IRI("http://example.org/a"), Predicate: rdf.IRI("http://example.org/b"), Object: rdf.Literal{Value: "plain"}},
+			{Subject: rdf.IRI("http://example.org/a"), Predicate: rdf.IRI("http://example.org/b"), Object: rdf.Literal{Value: "English", Lang: "en-US"}},
+			{Subject: rdf.IRI("http://example.org/a"), Predicate: rdf.IRI("http://example.org/b"), Object: rdf.Literal{Value: "عربي", Lang: "ar", Direction: rdf.DirectionRTL}},
+			{Subject: rdf.IRI("http://example.org/a"), Predicate: rdf.IRI("http://example.org/b"), Object: rdf.Literal{Value: "42", Datatype: "http://www.w3.org/2001/XMLSchema#integer"}},
+		},
+	},
+	"escapes": {
+		data: `<http://example.org/Česko> <http://example.org/b> "line\nbreak, \"quote\", \\ and é \U0001F577" .`,
+		expected: []rdf.Triple{
+			{Subject: rdf.IRI("http://example.org/Česko"), Predicate: rdf.IRI("http://example.org/b"), Object: rdf.Literal{Value: "line\nbreak, \"quote\", \\ and é 🕷"}},
+		},
+	},
+	"comments_and_empty_lines": {
+		data: "# a comment\n\n\t<http://example.org/a> <http://example.org/b> \"c\" . # another one\r\n   \n",
+		expected: []rdf.Triple{
+			{Subject: rdf.IRI("http://example.org/a"), Predicate: rdf.IRI("http://example.org/b"), Object: rdf.Literal{Value: "c"}},
+		},
+	},
+}
+
+func TestReader(t *testing.T) {
+	for name, tc := range readerTestCases {
+		t.Run(name, func(t *testing.T) {
+			r := ntriples.NewReader(strings.NewReader(tc.data))
+
+			var actual []rdf.Triple
+			for r.Next() {
+				actual = append(actual, r.Statement())
+			}
+
+			assert.NoError(t, r.Err(), "reader should have returned no error")
+			assert.Equal(t, tc.expected, actual, "reader should have returned the triples of the lines")
+		})
+	}
+}
+
+func TestReaderTripleWithAnnotations(t *testing.T) {
+	r := ntriples.NewReader(strings.NewReader(`_:a <http://example.org/b> _:c .
+<http://example.org/a> <http://example.org/b> "English"@en .`))
+
+	assert.Equal(t, true, r.Next(), "reader should have read the first triple")
+	assert.Equal(t, [6]string{"_:a", "http://example.org/b", "_:c", "", "", "iri"}, r.TripleWithAnnotations(), "reader should have returned the triple as the scanner does")
+	assert.Equal(t, true, r.Next(), "reader should have read the second triple")
+	assert.Equal(t, [6]string{"http://example.org/a", "http://example.org/b", "English", "en", "", "literal"}, r.TripleWithAnnotations(), "reader should have returned the triple as the scanner does")
+	assert.Equal(t, [3]string{"http://example.org/a", "http://example.org/b", "English"}, r.Triple(), "reader should have returned the triple as the scanner does")
+	assert.Equal(t, false, r.Next(), "reader should have reached the end of the data")
+	assert.Equal(t, [6]string{}, r.TripleWithAnnotations(), "reader should have returned an empty triple at the end")
+}
+
+var readerErrorTestCases = map[string]struct {
+	data     string
+	triples  int
+	expected scanner.SyntaxError
+}{
+	"relative_iri": {
+		data: "<http://example.org/a> <http://example.org/b> <http://example.org/c> .\n<a> <http://example.org/b> <http://example.org/c> .",
+		expected: scanner.SyntaxError{
+			Position: scanner.Position{Offset: 71, Line: 2, Column: 1},
+			Token:    "<a>",
+			Expected: "absolute IRI",
+		},
+		triples: 1,
+	},
+	"prefixed_name": {
+		data: "<http://example.org/a> ex:b <http://example.org/c> .",
+		expected: scanner.SyntaxError{
+			Position: scanner.Position{Offset: 23, Line: 1, Column: 24},
+			Token:    "ex:b",
+			Expected: "IRI",
+		},
+	},
+	"literal_subject": {
+		data: `"a" <http://example.org/b> <http://example.org/c> .`,
+		expected: scanner.SyntaxError{
+			Position: scanner.Position{Offset: 0, Line: 1, Column: 1},
+			Token:    `"a"`,
+			Expected: "IRI or blank node",
+		},
+	},
+	"missing_terminator": {
+		data: `<http://example.org/a> <http://example.org/b> "c"`,
+		expected: scanner.SyntaxError{
+			Position: scanner.Position{Offset: 49, Line: 1, Column: 50},
+			Token:    "\n",
+			Expected: `"."`,
+		},
+	},
+	"invalid_escape": {
+		data: `<http://example.org/a> <http://example.org/b> "Česká \x" .`,
+		expected: scanner.SyntaxError{
+			Position: scanner.Position{Offset: 55, Line: 1, Column: 54},
+			Token:    `\x"`,
+			Expected: "escape sequence",
+		},
+	},
+	"invalid_direction": {
+		data: `<http://example.org/a> <http://example.org/b> "c"@ar--up .`,
+		expected: scanner.SyntaxError{
+			Position: scanner.Position{Offset: 49, Line: 1, Column: 50},
+			Token:    "@ar--up",
+			Expected: "language tag",
+		},
+	},
+	"unterminated_literal": {
+		data: `<http://example.org/a> <http://example.org/b> "c .`,
+		expected: scanner.SyntaxError{
+			Position: scanner.Position{Offset: 50, Line: 1, Column: 51},
+			Token:    "\n",
+			Expected: `literal closed by "`,
+		},
+	},
+	"space_in_iri": {
+		data: `<http://example.org/a b> <http://example.org/b> "c" .`,
+		expected: scanner.SyntaxError{
+			Position: scanner.Position{Offset: 21, Line: 1, Column: 22},
+			Token:    " ",
+			Expected: "IRI character",
+		},
+	},
+	"graph_name": {
+		data: `<http://example.org/a> <http://example.org/b> "c" <http://example.org/g> .`,
+		expected: scanner.SyntaxError{
+			Position: scanner.Position{Offset: 50, Line: 1, Column: 51},
+			Token:    "<http://example.org/g>",
+			Expected: `"."`,
+		},
+	},
+	"two_triples_on_line": {
+		data: `<http://example.org/a> <http://example.org/b> "c" . <http://example.org/a> <http://example.org/b> "d" .`,
+		expected: scanner.SyntaxError{
+			Position: scanner.Position{Offset: 52, Line: 1, Column: 53},
+			Token:    "<http://example.org/a>",
+			Expected: "end of line",
+		},
+	},
+}
+
+func TestReaderError(t *testing.T) {
+	for name, tc := range readerErrorTestCases {
+		t.Run(name, func(t *testing.T) {
+			r := ntriples.NewReader(strings.NewReader(tc.data))
+
+			var triples int
+			for r.Next() {
+				triples++
+			}
+
+			assert.Equal(t, tc.triples, triples, "reader should have returned the triples preceding the error")
+
+			var syntaxErr *scanner.SyntaxError
+			if !errors.As(r.Err(), &syntaxErr) {
+				t.Fatalf("expected a syntax error, got: %v", r.Err())
+			}
+
+			assert.Equal(t, tc.expected, *syntaxErr, "reader should have returned a correct syntax error")
+			assert.Equal(t, false, r.Next(), "reader should not continue after an error")
+		})
+	}
+}
+
+func TestQuadReader(t *testing.T) {
+	r := ntriples.NewQuadReader(strings.NewReader(`<http://example.org/a> <http://example.org/b> "c" .
+<http://example.org/a> <http://example.org/b> "c" <http://example.org/g> .
+_:a <http://example.org/b> _:c _:g.
+`))
+
+	var actual []rdf.Quad
+	var names []string
+	for r.Next() {
+		actual = append(actual, r.Quad())
+		names = append(names, r.Graph())
+	}
+
+	assert.NoError(t, r.Err(), "reader should have returned no error")
+	assert.Equal(t, []rdf.Quad{
+		{Subject: rdf.IRI("http://example.org/a"), Predicate: rdf.IRI("http://example.org/b"), Object: rdf.Literal{Value: "c"}},
+		{Subject: rdf.IRI("http://example.org/a"), Predicate: rdf.IRI("http://example.org/b"), Object: rdf.Literal{Value: "c"}, Graph: rdf.IRI("http://example.org/g")},
+		{Subject: rdf.BlankNode("a"), Predicate: rdf.IRI("http://example.org/b"), Object: rdf.BlankNode("c"), Graph: rdf.BlankNode("g")},
+	}, actual, "reader should have returned the quads of the lines")
+	assert.Equal(t, []string{"", "http://example.org/g", "_:g"}, names, "reader should have returned the graph names")
+}
+
+func TestQuadReaderError(t *testing.T) {
+	r := ntriples.NewQuadReader(strings.NewReader(`<http://example.org/a> <http://example.org/b> "c" "g" .`))
+
+	assert.Equal(t, false, r.Next(), "reader should not have read the quad")
+
+	var syntaxErr *scanner.SyntaxError
+	if !errors.As(r.Err(), &syntaxErr) {
+		t.Fatalf("expected a syntax error, got: %v", r.Err())
+	}
+
+	assert.Equal(t, scanner.SyntaxError{
+		Position: scanner.Position{Offset: 50, Line: 1, Column: 51},
+		Token:    `"g"`,
+		Expected: `IRI, blank node or "."`,
+	}, *syntaxErr, "reader should have returned a correct syntax error")
+}
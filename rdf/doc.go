@@ -0,0 +1,6 @@
+// Package rdf contains the terms of the RDF data model, that is IRIs,
+// blank nodes, literals and the quoted triples of RDF-star, and the
+// triples and quads made of them.
+// Unlike the string arrays used by the scanner and the graph, the terms
+// know their kind and can be compared and written in the N-Triples form.
+package rdf
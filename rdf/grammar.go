@@ -0,0 +1,96 @@
+package rdf
+
+import (
+	"regexp"
+	"strings"
+)
+
+// datatypes of the literals that the Turtle and N-Triples grammars
+// have a special form for
+const (
+	XSDNamespace     = "http://www.w3.org/2001/XMLSchema#"
+	XSDString        = XSDNamespace + "string"
+	XSDBoolean       = XSDNamespace + "boolean"
+	XSDInteger       = XSDNamespace + "integer"
+	XSDDecimal       = XSDNamespace + "decimal"
+	XSDDouble        = XSDNamespace + "double"
+	RDFLangString    = "http://www.w3.org/1999/02/22-rdf-syntax-ns#langString"
+	RDFDirLangString = "http://www.w3.org/1999/02/22-rdf-syntax-ns#dirLangString"
+)
+
+// PNCharsBase is the PN_CHARS_BASE production of the Turtle and N-Triples
+// grammars, the characters that names can start with, as the content
+// of a regular expression character class.
+const PNCharsBase = `A-Za-z\x{00C0}-\x{00D6}\x{00D8}-\x{00F6}\x{00F8}-\x{02FF}\x{0370}-\x{037D}\x{037F}-\x{1FFF}` +
+	`\x{200C}-\x{200D}\x{2070}-\x{218F}\x{2C00}-\x{2FEF}\x{3001}-\x{D7FF}\x{F900}-\x{FDCF}\x{FDF0}-\x{FFFD}\x{10000}-\x{EFFFF}`
+
+var regexPNCharsBase = regexp.MustCompile(`^[` + PNCharsBase + `]$`)
+
+// IsPNCharsBase reports whether the character is one of PNCharsBase.
+func IsPNCharsBase(r rune) bool {
+	return regexPNCharsBase.MatchString(string(r))
+}
+
+// echars maps the characters of string escape sequences, e.g. the
+// letter n in \n, to the characters they stand for.
+var echars = map[byte]byte{
+	't':  '\t',
+	'b':  '\b',
+	'n':  '\n',
+	'r':  '\r',
+	'f':  '\f',
+	'"':  '"',
+	'\'': '\'',
+	'\\': '\\',
+}
+
+// escapes maps the characters that have a string escape sequence
+// to the letter following the backslash.
+var escapes = func() map[rune]byte {
+	m := make(map[rune]byte, len(echars))
+	for e, c := range echars {
+		m[rune(c)] = e
+	}
+	return m
+}()
+
+// UnescapeChar returns the character that the string escape sequence
+// with the given letter following the backslash stands for, e.g. a line
+// feed for the letter n. It reports false if there is no such sequence.
+func UnescapeChar(e byte) (byte, bool) {
+	c, ok := echars[e]
+	return c, ok
+}
+
+// EscapeChar returns the letter following the backslash in the string
+// escape sequence of the character, e.g. the letter n for a line feed.
+// It reports false if the character has no such sequence. Quotation
+// marks and apostrophes have one as well, whether they need it
+// depends on the quotes the string is enclosed in.
+func EscapeChar(r rune) (byte, bool) {
+	e, ok := escapes[r]
+	return e, ok
+}
+
+// IsQuotedTriple reports whether the string is a quoted triple in the
+// N-Triples form rather than an IRI or a blank node label.
+func IsQuotedTriple(str string) bool {
+	return strings.HasPrefix(str, "<< ")
+}
+
+// Resource returns the term of a string the scanner and the graph use
+// for resources: the quoted triple in the N-Triples form, the blank
+// node label with the _: prefix or else the IRI.
+func Resource(str string) Term {
+	if IsQuotedTriple(str) {
+		if t, err := ParseTerm(str); err == nil {
+			return t
+		}
+	}
+
+	if label, ok := strings.CutPrefix(str, "_:"); ok {
+		return BlankNode(label)
+	}
+
+	return IRI(str)
+}
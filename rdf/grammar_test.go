@@ -0,0 +1,63 @@
+package rdf_test
+
+import (
+	"testing"
+
+	"github.com/nvkp/turtle/assert"
+	"github.com/nvkp/turtle/rdf"
+)
+
+var resourceTestCases = map[string]struct {
+	str  string
+	term rdf.Term
+}{
+	"iri": {
+		str:  "http://example.org/a",
+		term: rdf.IRI("http://example.org/a"),
+	},
+	"blank_node": {
+		str:  "_:b0",
+		term: rdf.BlankNode("b0"),
+	},
+	"quoted_triple": {
+		str: "<< <http://example.org/a> <http://example.org/b> _:c >>",
+		term: rdf.QuotedTriple{
+			Subject:   rdf.IRI("http://example.org/a"),
+			Predicate: rdf.IRI("http://example.org/b"),
+			Object:    rdf.BlankNode("c"),
+		},
+	},
+}
+
+func TestResource(t *testing.T) {
+	for name, tc := range resourceTestCases {
+		t.Run(name, func(t *testing.T) {
+			term := rdf.Resource(tc.str)
+			assert.Equal(t, true, tc.term.Equal(term), "resource should have been read from its string")
+		})
+	}
+}
+
+func TestEscapeChar(t *testing.T) {
+	for _, e := range []byte(`tbnrf"'\`) {
+		c, ok := rdf.UnescapeChar(e)
+		assert.Equal(t, true, ok, "escape sequence should have been known")
+
+		escaped, ok := rdf.EscapeChar(rune(c))
+		assert.Equal(t, true, ok, "character should have had an escape sequence")
+		assert.Equal(t, e, escaped, "character should have been escaped by the same letter")
+	}
+
+	_, ok := rdf.UnescapeChar('x')
+	assert.Equal(t, false, ok, "unknown escape sequence should have been reported")
+}
+
+func TestIsPNCharsBase(t *testing.T) {
+	for _, r := range "aZÀͰ\U00010000" {
+		assert.Equal(t, true, rdf.IsPNCharsBase(r), "character should have been allowed")
+	}
+
+	for _, r := range "0_-.:×·" {
+		assert.Equal(t, false, rdf.IsPNCharsBase(r), "character should not have been allowed")
+	}
+}
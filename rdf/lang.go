@@ -0,0 +1,200 @@
+package rdf
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrInvalidLanguageTag is returned when a language tag is not a well-formed
+// BCP 47 tag or its base direction is neither ltr nor rtl.
+var ErrInvalidLanguageTag = errors.New("invalid language tag")
+
+const (
+	// DirectionLTR is the base direction of a text written left to right.
+	DirectionLTR = "ltr"
+	// DirectionRTL is the base direction of a text written right to left.
+	DirectionRTL = "rtl"
+)
+
+const directionDelimiter = "--"
+
+// irregular lists the grandfathered tags of BCP 47 that do not follow
+// the syntax of the other tags.
+var irregular = map[string]bool{
+	"en-gb-oed":  true,
+	"i-ami":      true,
+	"i-bnn":      true,
+	"i-default":  true,
+	"i-enochian": true,
+	"i-hak":      true,
+	"i-klingon":  true,
+	"i-lux":      true,
+	"i-mingo":    true,
+	"i-navajo":   true,
+	"i-pwn":      true,
+	"i-tao":      true,
+	"i-tay":      true,
+	"i-tsu":      true,
+	"sgn-be-fr":  true,
+	"sgn-be-nl":  true,
+	"sgn-ch-de":  true,
+}
+
+// SplitLanguageTag splits the language tag into the language and the
+// base direction following two hyphens, e.g. ar--rtl. The direction
+// is empty if there is none.
+func SplitLanguageTag(tag string) (string, string) {
+	lang, direction, _ := strings.Cut(tag, directionDelimiter)
+	return lang, direction
+}
+
+// JoinLanguageTag returns the language followed by the base direction
+// unless the direction is empty.
+func JoinLanguageTag(lang string, direction string) string {
+	if direction == "" {
+		return lang
+	}
+
+	return lang + directionDelimiter + direction
+}
+
+// NormalizeLanguageTag returns the language tag with the case of its
+// subtags normalized the way BCP 47 recommends, e.g. en-US or zh-Hant,
+// and with the base direction in lower case. The normalized tag is
+// returned together with ErrInvalidLanguageTag if the tag is not
+// well-formed.
+func NormalizeLanguageTag(tag string) (string, error) {
+	lang, direction := SplitLanguageTag(tag)
+	lang, direction = normalizeLanguage(lang), strings.ToLower(direction)
+	normalized := JoinLanguageTag(lang, direction)
+
+	if !isWellFormed(lang) {
+		return normalized, ErrInvalidLanguageTag
+	}
+
+	if strings.Contains(tag, directionDelimiter) && direction != DirectionLTR && direction != DirectionRTL {
+		return normalized, ErrInvalidLanguageTag
+	}
+
+	return normalized, nil
+}
+
+// normalizeLanguage writes the two letter subtags following the first one
+// in upper case, the four letter ones in title case and the others in
+// lower case. All the subtags following a singleton are in lower case.
+func normalizeLanguage(lang string) string {
+	subtags := strings.Split(strings.ToLower(lang), "-")
+
+	var singleton bool
+	for i, subtag := range subtags {
+		switch {
+		case i == 0 || singleton:
+		case len(subtag) == 2:
+			subtags[i] = strings.ToUpper(subtag)
+		case len(subtag) == 4:
+			subtags[i] = strings.ToUpper(subtag[:1]) + subtag[1:]
+		}
+
+		if len(subtag) == 1 {
+			singleton = true
+		}
+	}
+
+	return strings.Join(subtags, "-")
+}
+
+// isWellFormed reports whether the language tag follows the syntax of BCP 47,
+// that is the language, the script, the region, the variants, the extensions
+// and the private use subtags, or whether it is one of the irregular tags.
+func isWellFormed(lang string) bool {
+	lang = strings.ToLower(lang)
+	if irregular[lang] {
+		return true
+	}
+
+	subtags := strings.Split(lang, "-")
+	for _, subtag := range subtags {
+		if len(subtag) == 0 || len(subtag) > 8 || !isAlphanumeric(subtag) {
+			return false
+		}
+	}
+
+	if subtags[0] == "x" {
+		return len(subtags) > 1
+	}
+
+	// the language with up to three extended language subtags
+	i := 1
+	switch first := subtags[0]; {
+	case !isAlpha(first) || len(first) < 2:
+		return false
+	case len(first) <= 3:
+		for n := 0; n < 3 && i < len(subtags) && len(subtags[i]) == 3 && isAlpha(subtags[i]); n++ {
+			i++
+		}
+	}
+
+	// the script
+	if i < len(subtags) && len(subtags[i]) == 4 && isAlpha(subtags[i]) {
+		i++
+	}
+
+	// the region
+	if i < len(subtags) && (len(subtags[i]) == 2 && isAlpha(subtags[i]) || len(subtags[i]) == 3 && isDigit(subtags[i])) {
+		i++
+	}
+
+	// the variants
+	for i < len(subtags) && (len(subtags[i]) >= 5 || len(subtags[i]) == 4 && isDigit(subtags[i][:1])) {
+		i++
+	}
+
+	// the extensions, each of a singleton followed by at least one subtag
+	for i < len(subtags) && len(subtags[i]) == 1 && subtags[i] != "x" {
+		i++
+		start := i
+		for i < len(subtags) && len(subtags[i]) >= 2 {
+			i++
+		}
+		if i == start {
+			return false
+		}
+	}
+
+	// the private use subtags
+	if i < len(subtags) && subtags[i] == "x" {
+		return i+1 < len(subtags)
+	}
+
+	return i == len(subtags)
+}
+
+func isAlpha(str string) bool {
+	for _, r := range str {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isDigit(str string) bool {
+	for _, r := range str {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isAlphanumeric(str string) bool {
+	for _, r := range str {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+
+	return true
+}
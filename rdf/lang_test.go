@@ -0,0 +1,103 @@
+package rdf_test
+
+import (
+	"testing"
+
+	"github.com/nvkp/turtle/assert"
+	"github.com/nvkp/turtle/rdf"
+)
+
+var normalizeLanguageTagTestCases = map[string]struct {
+	tag      string
+	expected string
+	expErr   error
+}{
+	"language": {
+		tag:      "EN",
+		expected: "en",
+	},
+	"region": {
+		tag:      "EN-us",
+		expected: "en-US",
+	},
+	"script_and_region": {
+		tag:      "zh-hant-tw",
+		expected: "zh-Hant-TW",
+	},
+	"numeric_region": {
+		tag:      "es-419",
+		expected: "es-419",
+	},
+	"extended_language": {
+		tag:      "zh-yue-HK",
+		expected: "zh-yue-HK",
+	},
+	"variant": {
+		tag:      "sl-ROZAJ-biske",
+		expected: "sl-rozaj-biske",
+	},
+	"extension_and_private_use": {
+		tag:      "en-US-u-CA-gregory-x-AB",
+		expected: "en-US-u-ca-gregory-x-ab",
+	},
+	"private_use": {
+		tag:      "X-Whatever",
+		expected: "x-whatever",
+	},
+	"irregular": {
+		tag:      "I-Klingon",
+		expected: "i-klingon",
+	},
+	"direction": {
+		tag:      "AR--RTL",
+		expected: "ar--rtl",
+	},
+	"empty_subtag": {
+		tag:      "en--US",
+		expected: "en--us",
+		expErr:   rdf.ErrInvalidLanguageTag,
+	},
+	"long_subtag": {
+		tag:      "en-abcdefghi",
+		expected: "en-abcdefghi",
+		expErr:   rdf.ErrInvalidLanguageTag,
+	},
+	"digit_language": {
+		tag:      "1a",
+		expected: "1a",
+		expErr:   rdf.ErrInvalidLanguageTag,
+	},
+	"extension_without_subtag": {
+		tag:      "en-a",
+		expected: "en-a",
+		expErr:   rdf.ErrInvalidLanguageTag,
+	},
+	"invalid_direction": {
+		tag:      "ar--up",
+		expected: "ar--up",
+		expErr:   rdf.ErrInvalidLanguageTag,
+	},
+	"missing_direction": {
+		tag:      "ar--",
+		expected: "ar",
+		expErr:   rdf.ErrInvalidLanguageTag,
+	},
+}
+
+func TestNormalizeLanguageTag(t *testing.T) {
+	for name, tc := range normalizeLanguageTagTestCases {
+		t.Run(name, func(t *testing.T) {
+			tag, err := rdf.NormalizeLanguageTag(tc.tag)
+			assert.ErrorIs(t, err, tc.expErr, "function should have returned a correct error")
+			assert.Equal(t, tc.expected, tag, "function should have normalized the tag")
+		})
+	}
+}
+
+func TestSplitLanguageTag(t *testing.T) {
+	lang, direction := rdf.SplitLanguageTag("ar-EG--rtl")
+	assert.Equal(t, "ar-EG", lang, "function should have returned the language")
+	assert.Equal(t, rdf.DirectionRTL, direction, "function should have returned the direction")
+	assert.Equal(t, "ar-EG--rtl", rdf.JoinLanguageTag(lang, direction), "function should have joined the tag back")
+	assert.Equal(t, "en", rdf.JoinLanguageTag("en", ""), "function should have omitted the empty direction")
+}
@@ -0,0 +1,189 @@
+package rdf
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalidSyntax is returned by ParseTerm when the string
+// is not a term in the N-Triples form.
+var ErrInvalidSyntax = errors.New("invalid term syntax")
+
+// ParseTerm reads a term in the N-Triples form, the one returned
+// by the String method of the terms, quoted triples included.
+func ParseTerm(str string) (Term, error) {
+	p := &termParser{str: str}
+
+	t, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.skipSpace(); p.i != len(p.str) {
+		return nil, ErrInvalidSyntax
+	}
+
+	return t, nil
+}
+
+// termParser reads the terms of a string one after another.
+type termParser struct {
+	str string
+	i   int
+}
+
+func (p *termParser) term() (Term, error) {
+	rest := p.str[p.i:]
+	switch {
+	case strings.HasPrefix(rest, "<<"):
+		return p.quotedTriple()
+	case strings.HasPrefix(rest, "<"):
+		return p.iri()
+	case strings.HasPrefix(rest, "_:"):
+		return p.blankNode()
+	case strings.HasPrefix(rest, `"`):
+		return p.literal()
+	}
+
+	return nil, ErrInvalidSyntax
+}
+
+func (p *termParser) skipSpace() {
+	for p.i < len(p.str) && (p.str[p.i] == ' ' || p.str[p.i] == '\t') {
+		p.i++
+	}
+}
+
+func (p *termParser) quotedTriple() (Term, error) {
+	p.i += len("<<")
+
+	var terms [3]Term
+	for n := range terms {
+		p.skipSpace()
+
+		t, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		terms[n] = t
+	}
+
+	p.skipSpace()
+	if !strings.HasPrefix(p.str[p.i:], ">>") {
+		return nil, ErrInvalidSyntax
+	}
+	p.i += len(">>")
+
+	return QuotedTriple{Subject: terms[0], Predicate: terms[1], Object: terms[2]}, nil
+}
+
+func (p *termParser) iri() (IRI, error) {
+	end := strings.IndexByte(p.str[p.i:], '>')
+	if end == -1 {
+		return "", ErrInvalidSyntax
+	}
+
+	iri, err := unescape(p.str[p.i+1 : p.i+end])
+	if err != nil {
+		return "", err
+	}
+
+	p.i += end + 1
+	return IRI(iri), nil
+}
+
+func (p *termParser) blankNode() (Term, error) {
+	start := p.i + len("_:")
+	end := start
+	for end < len(p.str) && p.str[end] != ' ' && p.str[end] != '\t' {
+		end++
+	}
+
+	if end == start {
+		return nil, ErrInvalidSyntax
+	}
+
+	p.i = end
+	return BlankNode(p.str[start:end]), nil
+}
+
+func (p *termParser) literal() (Term, error) {
+	end := p.i + 1
+	for ; end < len(p.str) && p.str[end] != '"'; end++ {
+		if p.str[end] == '\\' {
+			end++
+		}
+	}
+
+	if end >= len(p.str) {
+		return nil, ErrInvalidSyntax
+	}
+
+	value, err := unescape(p.str[p.i+1 : end])
+	if err != nil {
+		return nil, err
+	}
+	p.i = end + 1
+
+	l := Literal{Value: value}
+	switch rest := p.str[p.i:]; {
+	case strings.HasPrefix(rest, "@"):
+		end := strings.IndexAny(rest, " \t")
+		if end == -1 {
+			end = len(rest)
+		}
+		l.Lang, l.Direction = SplitLanguageTag(rest[1:end])
+		p.i += end
+	case strings.HasPrefix(rest, "^^<"):
+		p.i += len("^^")
+		if l.Datatype, err = p.iri(); err != nil {
+			return nil, err
+		}
+	}
+
+	return l, nil
+}
+
+// unescape replaces the string and \u escape sequences by the characters
+// they stand for.
+func unescape(str string) (string, error) {
+	if !strings.Contains(str, `\`) {
+		return str, nil
+	}
+
+	var b strings.Builder
+	b.Grow(len(str))
+
+	for i := 0; i < len(str); i++ {
+		if str[i] != '\\' {
+			b.WriteByte(str[i])
+			continue
+		}
+
+		if i+1 == len(str) {
+			return "", ErrInvalidSyntax
+		}
+
+		if c, ok := echars[str[i+1]]; ok {
+			b.WriteByte(c)
+			i++
+			continue
+		}
+
+		n := map[byte]int{'u': 4, 'U': 8}[str[i+1]]
+		if n == 0 || i+2+n > len(str) {
+			return "", ErrInvalidSyntax
+		}
+
+		code, err := strconv.ParseUint(str[i+2:i+2+n], 16, 32)
+		if err != nil || !utf8.ValidRune(rune(code)) {
+			return "", ErrInvalidSyntax
+		}
+		b.WriteRune(rune(code))
+		i += 1 + n
+	}
+
+	return b.String(), nil
+}
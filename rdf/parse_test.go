@@ -0,0 +1,39 @@
+package rdf_test
+
+import (
+	"testing"
+
+	"github.com/nvkp/turtle/assert"
+	"github.com/nvkp/turtle/rdf"
+)
+
+func TestParseTerm(t *testing.T) {
+	for name, tc := range termStringTestCases {
+		t.Run(name, func(t *testing.T) {
+			term, err := rdf.ParseTerm(tc.expected)
+			assert.NoError(t, err, "no error was expected")
+			assert.Equal(t, true, tc.term.Equal(term), "term should have been read from its N-Triples form")
+		})
+	}
+}
+
+var parseTermErrorTestCases = map[string]string{
+	"empty":              "",
+	"prefixed_name":      "ex:a",
+	"unclosed_iri":       "<http://example.org/a",
+	"unclosed_literal":   `"a`,
+	"invalid_escape":     `"\x"`,
+	"short_escape":       `"\u00"`,
+	"trailing_term":      "<http://example.org/a> <http://example.org/b>",
+	"unclosed_quoted":    "<< <http://example.org/a> <http://example.org/b> <http://example.org/c>",
+	"missing_quoted_obj": "<< <http://example.org/a> <http://example.org/b> >>",
+}
+
+func TestParseTermError(t *testing.T) {
+	for name, data := range parseTermErrorTestCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := rdf.ParseTerm(data)
+			assert.ErrorIs(t, err, rdf.ErrInvalidSyntax, "malformed term should have been rejected")
+		})
+	}
+}
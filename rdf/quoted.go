@@ -0,0 +1,19 @@
+package rdf
+
+// QuotedTriple is a triple used as a term, the subject or the object
+// of another triple, as RDF-star defines it. Quoting a triple does not
+// assert it.
+type QuotedTriple Triple
+
+// Equal reports whether the other term is a quoted triple
+// consisting of the same terms.
+func (q QuotedTriple) Equal(other Term) bool {
+	o, ok := other.(QuotedTriple)
+	return ok && Triple(q).Equal(Triple(o))
+}
+
+// String returns the quoted triple in the N-Triples-star form,
+// the terms of the triple enclosed in double angle brackets.
+func (q QuotedTriple) String() string {
+	return "<< " + format(q.Subject) + " " + format(q.Predicate) + " " + format(q.Object) + " >>"
+}
@@ -0,0 +1,144 @@
+package rdf
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Term is a single RDF term, either IRI, BlankNode, Literal or QuotedTriple.
+type Term interface {
+	// Equal reports whether the other term is the same RDF term.
+	Equal(other Term) bool
+	// String returns the term in the N-Triples form.
+	String() string
+}
+
+// IRI is an absolute IRI.
+type IRI string
+
+// Equal reports whether the other term is the same IRI.
+func (i IRI) Equal(other Term) bool {
+	o, ok := other.(IRI)
+	return ok && i == o
+}
+
+// String returns the IRI enclosed in angle brackets with the characters
+// that cannot be part of it replaced by their \u escape sequences.
+func (i IRI) String() string {
+	var b strings.Builder
+	b.Grow(len(i) + 2)
+
+	b.WriteRune('<')
+	for _, r := range i {
+		if r <= ' ' || strings.ContainsRune(`<>"{}|^`+"`"+`\\`, r) {
+			fmt.Fprintf(&b, "\\u%04X", r)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	b.WriteRune('>')
+
+	return b.String()
+}
+
+// BlankNode is a blank node identified by its label without the _: prefix.
+type BlankNode string
+
+// Equal reports whether the other term is a blank node with the same label.
+func (n BlankNode) Equal(other Term) bool {
+	o, ok := other.(BlankNode)
+	return ok && n == o
+}
+
+// String returns the label of the blank node with the _: prefix.
+func (n BlankNode) String() string {
+	return "_:" + string(n)
+}
+
+// Literal is a literal value with either a language tag or a datatype.
+// The literal with neither of them has the xsd:string datatype, the one
+// with a language tag has the rdf:langString datatype, or the
+// rdf:dirLangString datatype if it has a base direction as well.
+type Literal struct {
+	Value     string
+	Lang      string
+	Direction string
+	Datatype  IRI
+}
+
+// Equal reports whether the other term is a literal of the same value,
+// language tag, base direction and datatype. The language tags are
+// compared case insensitively.
+func (l Literal) Equal(other Term) bool {
+	o, ok := other.(Literal)
+	return ok && l.Value == o.Value && strings.EqualFold(l.Lang, o.Lang) && l.Direction == o.Direction && l.datatype() == o.datatype()
+}
+
+// String returns the literal enclosed in quotation marks and escaped the way
+// canonical N-Triples does, followed by its language tag or its datatype
+// unless the datatype is xsd:string.
+func (l Literal) String() string {
+	str := fmt.Sprintf(`"%s"`, escapeString(l.Value))
+
+	switch datatype := l.datatype(); {
+	case l.Lang != "":
+		return str + "@" + JoinLanguageTag(l.Lang, l.Direction)
+	case datatype != XSDString:
+		return str + "^^" + datatype.String()
+	}
+
+	return str
+}
+
+// datatype returns the datatype of the literal, also the implicit one.
+func (l Literal) datatype() IRI {
+	switch {
+	case l.Lang != "" && l.Direction != "":
+		return RDFDirLangString
+	case l.Lang != "":
+		return RDFLangString
+	case l.Datatype == "":
+		return XSDString
+	}
+
+	return l.Datatype
+}
+
+// escapeString escapes the quotation marks, backslashes and all control
+// characters of the literal as the canonical form of N-Triples requires.
+func escapeString(str string) string {
+	var b strings.Builder
+	b.Grow(len(str))
+
+	for _, r := range str {
+		switch e, ok := EscapeChar(r); {
+		case r == '\'':
+			b.WriteRune(r)
+		case ok:
+			b.WriteRune('\\')
+			b.WriteByte(e)
+		case r <= 0x1F || r == 0x7F:
+			fmt.Fprintf(&b, `\u%04X`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+func equal(a Term, b Term) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return a.Equal(b)
+}
+
+func format(t Term) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.String()
+}
@@ -0,0 +1,131 @@
+package rdf_test
+
+import (
+	"testing"
+
+	"github.com/nvkp/turtle/assert"
+	"github.com/nvkp/turtle/rdf"
+)
+
+var termStringTestCases = map[string]struct {
+	term     rdf.Term
+	expected string
+}{
+	"iri": {
+		term:     rdf.IRI("http://example.org/a"),
+		expected: "<http://example.org/a>",
+	},
+	"iri_escapes": {
+		term:     rdf.IRI("http://example.org/a b<c>"),
+		expected: `<http://example.org/a\u0020b\u003Cc\u003E>`,
+	},
+	"blank_node": {
+		term:     rdf.BlankNode("b0"),
+		expected: "_:b0",
+	},
+	"plain_literal": {
+		term:     rdf.Literal{Value: "it's \"a\"\n\tČeská \\ literal\x7f"},
+		expected: `"it's \"a\"\n\tČeská \\ literal\u007F"`,
+	},
+	"string_literal": {
+		term:     rdf.Literal{Value: "a", Datatype: "http://www.w3.org/2001/XMLSchema#string"},
+		expected: `"a"`,
+	},
+	"language_literal": {
+		term:     rdf.Literal{Value: "a", Lang: "en"},
+		expected: `"a"@en`,
+	},
+	"directional_literal": {
+		term:     rdf.Literal{Value: "a", Lang: "ar", Direction: rdf.DirectionRTL},
+		expected: `"a"@ar--rtl`,
+	},
+	"typed_literal": {
+		term:     rdf.Literal{Value: "42", Datatype: "http://www.w3.org/2001/XMLSchema#integer"},
+		expected: `"42"^^<http://www.w3.org/2001/XMLSchema#integer>`,
+	},
+	"quoted_triple": {
+		term: rdf.QuotedTriple{
+			Subject:   rdf.QuotedTriple{Subject: rdf.BlankNode("b0"), Predicate: rdf.IRI("http://example.org/p"), Object: rdf.Literal{Value: "a b", Lang: "en"}},
+			Predicate: rdf.IRI("http://example.org/q"),
+			Object:    rdf.Literal{Value: "0.9", Datatype: "http://www.w3.org/2001/XMLSchema#decimal"},
+		},
+		expected: `<< << _:b0 <http://example.org/p> "a b"@en >> <http://example.org/q> "0.9"^^<http://www.w3.org/2001/XMLSchema#decimal> >>`,
+	},
+}
+
+func TestTermString(t *testing.T) {
+	for name, tc := range termStringTestCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.term.String(), "term should have been written in the N-Triples form")
+		})
+	}
+}
+
+var termEqualTestCases = map[string]struct {
+	a        rdf.Term
+	b        rdf.Term
+	expected bool
+}{
+	"same_iri": {
+		a:        rdf.IRI("http://example.org/a"),
+		b:        rdf.IRI("http://example.org/a"),
+		expected: true,
+	},
+	"different_iri": {
+		a: rdf.IRI("http://example.org/a"),
+		b: rdf.IRI("http://example.org/b"),
+	},
+	"iri_and_blank_node": {
+		a: rdf.IRI("b0"),
+		b: rdf.BlankNode("b0"),
+	},
+	"iri_and_literal": {
+		a: rdf.IRI("http://example.org/a"),
+		b: rdf.Literal{Value: "http://example.org/a"},
+	},
+	"same_blank_node": {
+		a:        rdf.BlankNode("b0"),
+		b:        rdf.BlankNode("b0"),
+		expected: true,
+	},
+	"implicit_string_datatype": {
+		a:        rdf.Literal{Value: "a"},
+		b:        rdf.Literal{Value: "a", Datatype: "http://www.w3.org/2001/XMLSchema#string"},
+		expected: true,
+	},
+	"language_case": {
+		a:        rdf.Literal{Value: "a", Lang: "en-US"},
+		b:        rdf.Literal{Value: "a", Lang: "en-us"},
+		expected: true,
+	},
+	"different_language": {
+		a: rdf.Literal{Value: "a", Lang: "en"},
+		b: rdf.Literal{Value: "a", Lang: "cs"},
+	},
+	"different_direction": {
+		a: rdf.Literal{Value: "a", Lang: "ar", Direction: rdf.DirectionRTL},
+		b: rdf.Literal{Value: "a", Lang: "ar", Direction: rdf.DirectionLTR},
+	},
+	"different_datatype": {
+		a: rdf.Literal{Value: "1", Datatype: "http://www.w3.org/2001/XMLSchema#integer"},
+		b: rdf.Literal{Value: "1", Datatype: "http://www.w3.org/2001/XMLSchema#decimal"},
+	},
+	"same_quoted_triple": {
+		a:        rdf.QuotedTriple{Subject: rdf.IRI("http://example.org/a"), Predicate: rdf.IRI("http://example.org/b"), Object: rdf.Literal{Value: "c"}},
+		b:        rdf.QuotedTriple{Subject: rdf.IRI("http://example.org/a"), Predicate: rdf.IRI("http://example.org/b"), Object: rdf.Literal{Value: "c", Datatype: "http://www.w3.org/2001/XMLSchema#string"}},
+		expected: true,
+	},
+	"quoted_triple_and_iri": {
+		a: rdf.QuotedTriple{Subject: rdf.IRI("http://example.org/a"), Predicate: rdf.IRI("http://example.org/b"), Object: rdf.IRI("http://example.org/c")},
+		b: rdf.IRI("http://example.org/a"),
+	},
+}
+
+func TestTermEqual(t *testing.T) {
+	for name, tc := range termEqualTestCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.a.Equal(tc.b), "terms should have been compared correctly")
+			assert.Equal(t, tc.expected, tc.b.Equal(tc.a), "terms should have been compared symmetrically")
+		})
+	}
+}
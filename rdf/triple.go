@@ -0,0 +1,47 @@
+package rdf
+
+// Triple is a single RDF statement. Its subject is an IRI or a blank
+// node, its predicate is an IRI and its object is any term.
+type Triple struct {
+	Subject   Term
+	Predicate Term
+	Object    Term
+}
+
+// Equal reports whether the other triple consists of the same terms.
+func (t Triple) Equal(other Triple) bool {
+	return equal(t.Subject, other.Subject) && equal(t.Predicate, other.Predicate) && equal(t.Object, other.Object)
+}
+
+// String returns the triple as a line of N-Triples without the line break.
+func (t Triple) String() string {
+	return format(t.Subject) + " " + format(t.Predicate) + " " + format(t.Object) + " ."
+}
+
+// Quad is a triple together with the name of the graph it belongs to.
+// The triple of the default graph has no graph name.
+type Quad struct {
+	Subject   Term
+	Predicate Term
+	Object    Term
+	Graph     Term
+}
+
+// Triple returns the quad without its graph name.
+func (q Quad) Triple() Triple {
+	return Triple{Subject: q.Subject, Predicate: q.Predicate, Object: q.Object}
+}
+
+// Equal reports whether the other quad consists of the same terms.
+func (q Quad) Equal(other Quad) bool {
+	return q.Triple().Equal(other.Triple()) && equal(q.Graph, other.Graph)
+}
+
+// String returns the quad as a line of N-Quads without the line break.
+func (q Quad) String() string {
+	if q.Graph == nil {
+		return q.Triple().String()
+	}
+
+	return format(q.Subject) + " " + format(q.Predicate) + " " + format(q.Object) + " " + q.Graph.String() + " ."
+}
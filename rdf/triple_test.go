@@ -0,0 +1,44 @@
+package rdf_test
+
+import (
+	"testing"
+
+	"github.com/nvkp/turtle/assert"
+	"github.com/nvkp/turtle/rdf"
+)
+
+func TestTriple(t *testing.T) {
+	triple := rdf.Triple{
+		Subject:   rdf.BlankNode("b0"),
+		Predicate: rdf.IRI("http://xmlns.com/foaf/0.1/name"),
+		Object:    rdf.Literal{Value: "Alice", Lang: "en"},
+	}
+
+	assert.Equal(t, `_:b0 <http://xmlns.com/foaf/0.1/name> "Alice"@en .`, triple.String(), "triple should have been written as a line of N-Triples")
+	assert.Equal(t, true, triple.Equal(rdf.Triple{
+		Subject:   rdf.BlankNode("b0"),
+		Predicate: rdf.IRI("http://xmlns.com/foaf/0.1/name"),
+		Object:    rdf.Literal{Value: "Alice", Lang: "EN"},
+	}), "triples of the same terms should have been equal")
+	assert.Equal(t, false, triple.Equal(rdf.Triple{
+		Subject:   rdf.BlankNode("b0"),
+		Predicate: rdf.IRI("http://xmlns.com/foaf/0.1/name"),
+	}), "triple without an object should not have been equal")
+}
+
+func TestQuad(t *testing.T) {
+	quad := rdf.Quad{
+		Subject:   rdf.IRI("http://example.org/alice"),
+		Predicate: rdf.IRI("http://xmlns.com/foaf/0.1/name"),
+		Object:    rdf.Literal{Value: "Alice"},
+		Graph:     rdf.IRI("http://example.org/people"),
+	}
+
+	assert.Equal(t, `<http://example.org/alice> <http://xmlns.com/foaf/0.1/name> "Alice" <http://example.org/people> .`, quad.String(), "quad should have been written as a line of N-Quads")
+	assert.Equal(t, `<http://example.org/alice> <http://xmlns.com/foaf/0.1/name> "Alice" .`, quad.Triple().String(), "triple of the quad should have had no graph name")
+	assert.Equal(t, false, quad.Equal(rdf.Quad{
+		Subject:   quad.Subject,
+		Predicate: quad.Predicate,
+		Object:    quad.Object,
+	}), "quads of different graphs should not have been equal")
+}
@@ -0,0 +1,646 @@
+package turtle
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/nvkp/turtle/rdf"
+)
+
+const (
+	// TagID is for `turtle:"@id"` and marks the field holding
+	// the subject of a struct describing a resource.
+	TagID = "@id"
+
+	rdfTypeIRI  = "http://www.w3.org/1999/02/22-rdf-syntax-ns#type"
+	rdfFirstIRI = "http://www.w3.org/1999/02/22-rdf-syntax-ns#first"
+	rdfRestIRI  = "http://www.w3.org/1999/02/22-rdf-syntax-ns#rest"
+	rdfNilIRI   = "http://www.w3.org/1999/02/22-rdf-syntax-ns#nil"
+)
+
+// ErrUndefinedPrefix is returned by Marshal when a struct tag contains
+// a prefixed name whose prefix is not configured
+var ErrUndefinedPrefix = errors.New("undefined prefix in struct tag")
+
+// tripleTags are the tags of the structs describing a single triple.
+var tripleTags = map[string]bool{
+	"subject":    true,
+	"predicate":  true,
+	"object":     true,
+	"label":      true,
+	"direction":  true,
+	"datatype":   true,
+	"objecttype": true,
+	"base":       true,
+	"prefix":     true,
+	"graph":      true,
+}
+
+// isResource reports whether the struct describes a resource, that is
+// it has the `turtle:"@id"` field or fields tagged by predicates, instead
+// of describing a single triple.
+func isResource(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		tag, _ := parseTag(t.Field(i).Tag.Get("turtle"))
+		if tag != "" && !tripleTags[tag] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseTag splits the struct tag into the name and the comma
+// separated options following it, e.g. "ex:authors,list".
+func parseTag(tag string) (string, []string) {
+	// the IRI in angle brackets can contain commas
+	start := 0
+	if strings.HasPrefix(tag, "<") {
+		start = strings.Index(tag, ">") + 1
+	}
+
+	i := strings.Index(tag[start:], ",")
+	if i == -1 {
+		return tag, nil
+	}
+
+	return tag[:start+i], strings.Split(tag[start+i+1:], ",")
+}
+
+// hasOption reports whether the option is among the tag's options.
+func hasOption(options []string, option string) bool {
+	for _, o := range options {
+		if o == option {
+			return true
+		}
+	}
+
+	return false
+}
+
+// expandTag returns the predicate IRI given by the struct tag. The tag
+// is either a prefixed name, an IRI enclosed in angle brackets, an IRI
+// containing "://" or the keyword a.
+func expandTag(tag string, prefixes map[string]string) (string, error) {
+	if tag == "a" {
+		return rdfTypeIRI, nil
+	}
+
+	if strings.HasPrefix(tag, "<") && strings.HasSuffix(tag, ">") {
+		return tag[1 : len(tag)-1], nil
+	}
+
+	if strings.Contains(tag, "://") {
+		return tag, nil
+	}
+
+	prefix, local, ok := strings.Cut(tag, ":")
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUndefinedPrefix, tag)
+	}
+
+	namespace, ok := prefixes[prefix]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUndefinedPrefix, tag)
+	}
+
+	return namespace + local, nil
+}
+
+// predicate returns the IRI of the predicate given by the struct tag.
+// Without resolving the IRIs the tag is passed as it is.
+func (m *marshaller) predicate(tag string) (string, error) {
+	if m.verbatim {
+		return strings.TrimSuffix(strings.TrimPrefix(tag, "<"), ">"), nil
+	}
+
+	return expandTag(tag, m.prefixes)
+}
+
+// marshalResource extracts a triple for every populated field
+// of the struct with the value of the `turtle:"@id"` field
+// as their subject.
+func (m *marshaller) marshalResource(v reflect.Value) error {
+	_, err := m.marshalNested(v)
+	return err
+}
+
+// marshalNested extracts the triples of the resource and
+// returns its subject. A resource without `turtle:"@id"` becomes
+// a blank node. A pointer, or an addressable struct, that has already
+// been marshalled is only referred to by its subject so that cycles end.
+// The same goes for a resource whose subject is being marshalled already.
+func (m *marshaller) marshalNested(v reflect.Value) (string, error) {
+	if v.Kind() != reflect.Pointer && v.CanAddr() {
+		v = v.Addr()
+	}
+
+	if v.Kind() != reflect.Pointer {
+		id := resourceID(v)
+		if id == "" {
+			id = m.newBlankNode()
+		}
+		return id, m.marshalSubject(id, v)
+	}
+
+	if v.IsNil() {
+		return "", nil
+	}
+
+	key := visit{ptr: v.Pointer(), typ: v.Type()}
+	if id, ok := m.visited[key]; ok {
+		return id, nil
+	}
+
+	id := resourceID(v.Elem())
+	if id == "" {
+		id = m.newBlankNode()
+	}
+
+	if m.visited == nil {
+		m.visited = make(map[visit]string)
+	}
+	m.visited[key] = id
+
+	return id, m.marshalSubject(id, v.Elem())
+}
+
+// marshalSubject extracts the triples of the fields unless
+// the resource of the same subject is being marshalled already.
+func (m *marshaller) marshalSubject(id string, v reflect.Value) error {
+	if m.active[id] {
+		return nil
+	}
+
+	if m.active == nil {
+		m.active = make(map[string]bool)
+	}
+	m.active[id] = true
+	defer delete(m.active, id)
+
+	return m.marshalFields(id, v)
+}
+
+func (m *marshaller) marshalFields(id string, v reflect.Value) error {
+	for i := 0; i < v.NumField(); i++ {
+		tag, options := parseTag(v.Type().Field(i).Tag.Get("turtle"))
+		if tag == "" || tag == TagID || tripleTags[tag] {
+			continue
+		}
+
+		iri, err := m.predicate(tag)
+		if err != nil {
+			return err
+		}
+
+		if hasOption(options, "list") {
+			err = m.marshalList(id, iri, v.Field(i))
+		} else {
+			err = m.marshalPredicate(id, iri, v.Field(i))
+		}
+
+		if err != nil {
+			return fmt.Errorf("field %s: %w", v.Type().Field(i).Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (m *marshaller) newBlankNode() string {
+	label := fmt.Sprintf("_:b%d", m.blankNodes)
+	m.blankNodes++
+	return label
+}
+
+// marshalPredicate extracts the triples of the field. Every element of
+// a slice or an array is a separate object, zero values are omitted.
+func (m *marshaller) marshalPredicate(id string, iri string, v reflect.Value) error {
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		for i := 0; i < v.Len(); i++ {
+			if _, err := m.marshalObject(id, iri, v.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if v.IsZero() {
+		return nil
+	}
+
+	_, err := m.marshalObject(id, iri, v)
+	return err
+}
+
+// marshalObject extracts the triple with the value as its object.
+// It reports whether the triple was extracted.
+func (m *marshaller) marshalObject(id string, iri string, v reflect.Value) (bool, error) {
+	t := [6]string{subject: id, predicate: iri}
+
+	term, handled, err := marshalTerm(v)
+	if err != nil {
+		return false, err
+	}
+
+	switch {
+	case handled:
+		t[object], t[label], t[datatype], t[objecttype] = term.Value, rdf.JoinLanguageTag(term.Label, term.Direction), term.Datatype, term.Type
+		if err := checkDirection(t[label]); err != nil {
+			return false, err
+		}
+	case v.Kind() == reflect.Pointer && v.IsNil():
+		// nothing to write for nil pointers
+	case v.Kind() == reflect.String:
+		t[object] = v.String()
+	case v.Kind() == reflect.Pointer && v.Type().Elem().Kind() == reflect.String:
+		if !v.IsNil() {
+			t[object] = v.Elem().String()
+		}
+	case isResource(v.Type()) || v.Kind() == reflect.Pointer && isResource(v.Type().Elem()):
+		id, err := m.marshalNested(v)
+		if err != nil {
+			return false, err
+		}
+		t[object] = id
+		t[objecttype] = TypeIRI
+	default:
+		return false, fmt.Errorf("%w: %s", ErrInvalidValueType, v.Type())
+	}
+
+	// nothing to write for nil pointers and empty strings
+	if t[object] == "" {
+		return false, nil
+	}
+
+	return true, m.g.AcceptWithAnnotations(t)
+}
+
+// resourceID returns the value of the `turtle:"@id"` field.
+func resourceID(v reflect.Value) string {
+	for i := 0; i < v.NumField(); i++ {
+		if v.Type().Field(i).Tag.Get("turtle") != TagID {
+			continue
+		}
+
+		field := v.Field(i)
+		if field.Kind() == reflect.Pointer && !field.IsNil() {
+			field = field.Elem()
+		}
+		if field.Kind() == reflect.String {
+			return field.String()
+		}
+	}
+
+	return ""
+}
+
+// resource holds the triples of a single subject in the order
+// they were read.
+type resource struct {
+	id      string
+	triples [][6]string
+}
+
+// resources groups the triples by their subjects and fills
+// the resource structs with them.
+type resources struct {
+	list  []*resource
+	index map[string]*resource
+	// referrers holds the subjects of the triples whose objects are
+	// the blank nodes, those are nested in the referring resources
+	referrers map[string][]string
+	// emitted holds the unreferenced blank nodes already decoded
+	emitted  map[string]bool
+	prefixes map[string]string
+	// pointers holds the pointers to the already filled resources
+	// so that the cycles and shared resources point to the same value
+	pointers map[pointer]reflect.Value
+	// active holds the resources being filled at the moment
+	active map[string]bool
+}
+
+// pointer identifies a pointer to the resource of the type.
+type pointer struct {
+	id  string
+	typ reflect.Type
+}
+
+func newResources(prefixes map[string]string) *resources {
+	return &resources{
+		index:     make(map[string]*resource),
+		referrers: make(map[string][]string),
+		emitted:   make(map[string]bool),
+		prefixes:  prefixes,
+		pointers:  make(map[pointer]reflect.Value),
+		active:    make(map[string]bool),
+	}
+}
+
+// add appends the triple to the resource of its subject.
+func (rs *resources) add(t [6]string) {
+	rs.reference(t)
+
+	r, ok := rs.index[t[subject]]
+	if !ok {
+		r = &resource{id: t[subject]}
+		rs.index[t[subject]] = r
+		rs.list = append(rs.list, r)
+	}
+	r.triples = append(r.triples, t)
+}
+
+// reference marks the blank node object of the triple as referenced.
+func (rs *resources) reference(t [6]string) {
+	if t[objecttype] != TypeLiteral && isBlankNode(t[object]) {
+		rs.referrers[t[object]] = append(rs.referrers[t[object]], t[subject])
+	}
+}
+
+// roots returns the resources that are not nested in other ones.
+// The blank nodes of a cycle that nothing else reaches are all
+// referenced, the first node of the cycle is a root as well so
+// that the cycle is not lost.
+func (rs *resources) roots() []*resource {
+	root := make(map[string]bool)
+	reached := make(map[string]bool)
+	for _, r := range rs.list {
+		if len(rs.referrers[r.id]) == 0 {
+			root[r.id] = true
+			rs.reach(r.id, reached)
+		}
+	}
+
+	// the blank nodes nested in the resources already decoded
+	// one by one are reached from outside of the list
+	for node, referrers := range rs.referrers {
+		for _, referrer := range referrers {
+			if _, ok := rs.index[referrer]; !ok {
+				rs.reach(node, reached)
+			}
+		}
+	}
+
+	for _, r := range rs.list {
+		if !reached[r.id] && rs.inCycle(r.id) {
+			root[r.id] = true
+			rs.reach(r.id, reached)
+		}
+	}
+
+	var roots []*resource
+	for _, r := range rs.list {
+		if root[r.id] {
+			roots = append(roots, r)
+		}
+	}
+
+	return roots
+}
+
+// reach marks the subject and the blank nodes nested in it as reached.
+func (rs *resources) reach(id string, reached map[string]bool) {
+	if reached[id] {
+		return
+	}
+	reached[id] = true
+
+	r, ok := rs.index[id]
+	if !ok {
+		return
+	}
+
+	for _, t := range r.triples {
+		if t[objecttype] != TypeLiteral && isBlankNode(t[object]) {
+			rs.reach(t[object], reached)
+		}
+	}
+}
+
+// inCycle reports whether the blank nodes nested in the subject lead back to it.
+func (rs *resources) inCycle(id string) bool {
+	reached := make(map[string]bool)
+	for _, t := range rs.index[id].triples {
+		if t[objecttype] != TypeLiteral && isBlankNode(t[object]) {
+			rs.reach(t[object], reached)
+		}
+	}
+
+	return reached[id]
+}
+
+// nextRoot returns the next unreferenced blank node
+// that has not been returned yet.
+func (rs *resources) nextRoot() *resource {
+	for _, r := range rs.roots() {
+		if !rs.emitted[r.id] {
+			rs.emitted[r.id] = true
+			return r
+		}
+	}
+
+	return nil
+}
+
+// hasRoot reports whether there is an unreferenced
+// blank node that has not been returned yet.
+func (rs *resources) hasRoot() bool {
+	for _, r := range rs.roots() {
+		if !rs.emitted[r.id] {
+			return true
+		}
+	}
+
+	return false
+}
+
+func unmarshalResources(s source, v reflect.Value) error {
+	rs := newResources(s.Prefixes())
+
+	for s.Next() {
+		t := s.TripleWithAnnotations()
+		// skip the pragmas
+		if t[subject] != "" {
+			rs.add(t)
+		}
+	}
+
+	if err := s.Err(); err != nil {
+		return err
+	}
+
+	roots := rs.roots()
+
+	if v.Kind() == reflect.Struct {
+		if len(roots) == 0 {
+			return nil
+		}
+		return rs.unmarshal(v, roots[0])
+	}
+
+	itemType := v.Type().Elem()
+	for _, r := range roots {
+		var item reflect.Value
+		var err error
+		if itemType.Kind() == reflect.Pointer {
+			item, err = rs.pointer(itemType, r.id)
+		} else {
+			item = reflect.New(itemType).Elem()
+			err = rs.unmarshal(item, r)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		v.Set(reflect.Append(v, item))
+	}
+
+	return nil
+}
+
+// unmarshal fills the struct with the subject and the objects
+// of the resource's triples. A slice field collects all the objects
+// of its predicate, any other field gets the first one.
+func (rs *resources) unmarshal(v reflect.Value, r *resource) error {
+	rs.active[r.id] = true
+	defer delete(rs.active, r.id)
+
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		tag, options := parseTag(v.Type().Field(i).Tag.Get("turtle"))
+		if tag == "" || tripleTags[tag] {
+			continue
+		}
+
+		if tag == TagID {
+			// the labels of the blank nodes are not kept
+			if !isBlankNode(r.id) {
+				setString(field, r.id)
+			}
+			continue
+		}
+
+		// the tag is compared as written as well
+		// for the IRIs that are not expanded
+		written := strings.TrimSuffix(strings.TrimPrefix(tag, "<"), ">")
+		iri, err := expandTag(tag, rs.prefixes)
+		if err != nil {
+			iri = written
+		}
+
+		for _, t := range r.triples {
+			if t[predicate] != iri && t[predicate] != written {
+				continue
+			}
+
+			if hasOption(options, "list") {
+				err = rs.unmarshalList(field, t[object])
+			} else {
+				err = rs.unmarshalObject(field, t)
+			}
+
+			if err != nil {
+				return fmt.Errorf("field %s: %w", v.Type().Field(i).Name, err)
+			}
+
+			if field.Kind() != reflect.Slice || hasOption(options, "list") {
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// unmarshalObject sets the object of the triple to the field
+// or appends it if the field is a slice.
+func (rs *resources) unmarshalObject(field reflect.Value, t [6]string) error {
+	if field.Kind() != reflect.Slice {
+		return rs.setObject(field, t)
+	}
+
+	item := reflect.New(field.Type().Elem()).Elem()
+	if err := rs.setObject(item, t); err != nil {
+		return err
+	}
+	field.Set(reflect.Append(field, item))
+
+	return nil
+}
+
+func (rs *resources) setObject(v reflect.Value, t [6]string) error {
+	if handled, err := unmarshalTerm(v, objectTerm(t)); handled {
+		return err
+	}
+
+	switch {
+	case isResource(v.Type()):
+		return rs.unmarshalNested(v, t[object])
+	case v.Kind() == reflect.Pointer && isResource(v.Type().Elem()):
+		ptr, err := rs.pointer(v.Type(), t[object])
+		if err != nil {
+			return err
+		}
+		v.Set(ptr)
+		return nil
+	}
+
+	setString(v, t[object])
+	return nil
+}
+
+// unmarshalNested fills the nested struct with the triples of its
+// subject. A resource being filled already up the tree is only
+// given its subject to end the cycle.
+func (rs *resources) unmarshalNested(v reflect.Value, id string) error {
+	r, ok := rs.index[id]
+	if !ok || rs.active[id] {
+		r = &resource{id: id}
+	}
+
+	return rs.unmarshal(v, r)
+}
+
+// pointer returns the pointer to the resource of the subject. All the
+// references to the same subject share the pointer, which is filled
+// the first time it is needed.
+func (rs *resources) pointer(typ reflect.Type, id string) (reflect.Value, error) {
+	key := pointer{id: id, typ: typ}
+	if ptr, ok := rs.pointers[key]; ok {
+		return ptr, nil
+	}
+
+	ptr := reflect.New(typ.Elem())
+	rs.pointers[key] = ptr
+
+	r, ok := rs.index[id]
+	if !ok {
+		r = &resource{id: id}
+	}
+
+	return ptr, rs.unmarshal(ptr.Elem(), r)
+}
+
+func isBlankNode(str string) bool {
+	return strings.HasPrefix(str, "_:")
+}
+
+// setString sets the string or the pointer to string value.
+// Values of other types are left untouched.
+func setString(v reflect.Value, str string) {
+	switch {
+	case v.Kind() == reflect.String:
+		v.SetString(str)
+	case v.Kind() == reflect.Pointer && v.Type().Elem().Kind() == reflect.String:
+		ptr := reflect.New(v.Type().Elem())
+		ptr.Elem().SetString(str)
+		v.Set(ptr)
+	}
+}
@@ -0,0 +1,454 @@
+package turtle_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nvkp/turtle"
+	"github.com/nvkp/turtle/assert"
+	"github.com/nvkp/turtle/graph"
+	"github.com/nvkp/turtle/ntriples"
+)
+
+type person struct {
+	ID       string   `turtle:"@id"`
+	Type     string   `turtle:"a"`
+	Name     string   `turtle:"foaf:name"`
+	Nick     []string `turtle:"foaf:nick"`
+	Age      int      `turtle:"foaf:age"`
+	Homepage *string  `turtle:"<http://xmlns.com/foaf/0.1/homepage>"`
+}
+
+var foafConfig = turtle.Config{
+	ResolveURLs: true,
+	Prefixes: map[string]string{
+		"foaf": "http://xmlns.com/foaf/0.1/",
+	},
+}
+
+var marshalResourceTestCases = map[string]struct {
+	resources interface{}
+	expString string
+	expErr    error
+}{
+	"one_resource": {
+		resources: person{
+			ID:   "http://example.org/alice",
+			Type: "http://xmlns.com/foaf/0.1/Person",
+			Name: "Alice",
+			Nick: []string{"Al", "Ally"},
+			Age:  30,
+		},
+		expString: `@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+<http://example.org/alice> 
+	<http://www.w3.org/1999/02/22-rdf-syntax-ns#type> foaf:Person ;
+	foaf:age 30 ;
+	foaf:name "Alice" ;
+	foaf:nick "Al", "Ally" .
+`,
+	},
+	"zero_values_omitted": {
+		resources: person{
+			ID:       "http://example.org/bob",
+			Homepage: ptr("http://bob.example.org/"),
+		},
+		expString: `@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+<http://example.org/bob> foaf:homepage <http://bob.example.org/> .
+`,
+	},
+	"slice_of_resources": {
+		resources: []*person{
+			{ID: "http://example.org/bob", Name: "Bob"},
+			{ID: "http://example.org/alice", Name: "Alice"},
+		},
+		expString: `@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+<http://example.org/alice> foaf:name "Alice" .
+<http://example.org/bob> foaf:name "Bob" .
+`,
+	},
+	"no_id": {
+		resources: person{Name: "Nobody"},
+		expString: `@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+[] foaf:name "Nobody" .
+`,
+	},
+	"undefined_prefix": {
+		resources: struct {
+			ID   string `turtle:"@id"`
+			Name string `turtle:"schema:name"`
+		}{ID: "http://example.org/alice", Name: "Alice"},
+		expErr: turtle.ErrUndefinedPrefix,
+	},
+	"invalid_field_type": {
+		resources: struct {
+			ID   string         `turtle:"@id"`
+			Tags map[string]int `turtle:"foaf:tags"`
+		}{ID: "http://example.org/alice", Tags: map[string]int{"a": 1}},
+		expErr: turtle.ErrInvalidValueType,
+	},
+}
+
+func TestMarshalResource(t *testing.T) {
+	for name, tc := range marshalResourceTestCases {
+		t.Run(name, func(t *testing.T) {
+			b, err := foafConfig.Marshal(tc.resources)
+			assert.ErrorIs(t, err, tc.expErr, "Marshal function should have returned a correct error")
+			assert.Equal(t, tc.expString, string(b), "Marshal function should have returned a correct byte data")
+		})
+	}
+}
+
+func TestUnmarshalResources(t *testing.T) {
+	data := []byte(`@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+@prefix ex: <http://example.org/> .
+
+ex:alice a foaf:Person ;
+	foaf:name "Alice" ;
+	foaf:nick "Al", "Ally" ;
+	foaf:age 30 .
+
+ex:bob foaf:name "Bob" ;
+	foaf:homepage <http://bob.example.org/> ;
+	foaf:knows ex:alice .
+
+ex:alice foaf:nick "A." .`)
+	expected := []person{
+		{
+			ID:   "http://example.org/alice",
+			Type: "http://xmlns.com/foaf/0.1/Person",
+			Name: "Alice",
+			Nick: []string{"Al", "Ally", "A."},
+			Age:  30,
+		},
+		{
+			ID:       "http://example.org/bob",
+			Name:     "Bob",
+			Homepage: ptr("http://bob.example.org/"),
+		},
+	}
+
+	var target []person
+	err := turtle.Unmarshal(data, &target)
+	assert.NoError(t, err, "Unmarshal function should have returned no error")
+	assert.Equal(t, expected, target, "Unmarshal function should have grouped the triples by their subjects")
+
+	var first person
+	err = turtle.Unmarshal(data, &first)
+	assert.NoError(t, err, "Unmarshal function should have returned no error")
+	assert.Equal(t, expected[0], first, "Unmarshal function should have filled the first resource")
+}
+
+func TestUnmarshalResourceInvalidLiteral(t *testing.T) {
+	data := []byte(`@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+<http://example.org/alice> foaf:age "thirty" .`)
+
+	var target person
+	err := turtle.Unmarshal(data, &target)
+	assert.ErrorIs(t, err, turtle.ErrInvalidLiteral, "Unmarshal function should have returned a literal error")
+}
+
+func TestMarshalUnmarshalResources(t *testing.T) {
+	resources := []person{
+		{
+			ID:   "http://example.org/alice",
+			Type: "http://xmlns.com/foaf/0.1/Person",
+			Name: "Alice",
+			Nick: []string{"Al", "Ally"},
+			Age:  30,
+		},
+		{
+			ID:       "http://example.org/bob",
+			Name:     "Bob",
+			Homepage: ptr("http://bob.example.org/"),
+		},
+	}
+
+	b, err := foafConfig.Marshal(resources)
+	assert.NoError(t, err, "Marshal function should have returned no error")
+
+	var target []person
+	err = foafConfig.Unmarshal(b, &target)
+	assert.NoError(t, err, "Unmarshal function should have returned no error")
+	assert.Equal(t, resources, target, "Unmarshal function should have returned the marshalled resources")
+}
+
+func TestDecoderResources(t *testing.T) {
+	data := `@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+<http://example.org/alice> foaf:name "Alice" ; foaf:nick "Al", "Ally" .
+<http://example.org/bob> foaf:name "Bob" .`
+	expected := []person{
+		{ID: "http://example.org/alice", Name: "Alice", Nick: []string{"Al", "Ally"}},
+		{ID: "http://example.org/bob", Name: "Bob"},
+	}
+
+	d := turtle.NewDecoder(strings.NewReader(data))
+
+	actual := make([]person, 0)
+	for d.More() {
+		var target person
+		err := d.Decode(&target)
+		assert.NoError(t, err, "method Decode should have returned no error")
+		actual = append(actual, target)
+	}
+
+	assert.Equal(t, expected, actual, "decoder should have decoded a resource per subject")
+}
+
+type address struct {
+	Street string `turtle:"ex:street"`
+	City   string `turtle:"ex:city"`
+}
+
+type employee struct {
+	ID      string      `turtle:"@id"`
+	Name    string      `turtle:"foaf:name"`
+	Address address     `turtle:"ex:address"`
+	Manager *employee   `turtle:"ex:manager"`
+	Friends []*employee `turtle:"foaf:knows"`
+}
+
+type node struct {
+	ID   string   `turtle:"@id"`
+	Tags []string `turtle:"ex:tags,list"`
+	Next *node    `turtle:"ex:next"`
+}
+
+var exConfig = turtle.Config{
+	ResolveURLs: true,
+	Prefixes: map[string]string{
+		"ex":   "http://example.org/",
+		"foaf": "http://xmlns.com/foaf/0.1/",
+	},
+}
+
+func TestMarshalNested(t *testing.T) {
+	alice := &employee{
+		ID:      "http://example.org/alice",
+		Name:    "Alice",
+		Address: address{Street: "Main St", City: "Springfield"},
+	}
+	bob := &employee{
+		ID:      "http://example.org/bob",
+		Name:    "Bob",
+		Manager: alice,
+	}
+	alice.Manager = bob
+	alice.Friends = []*employee{{Name: "Carol"}, bob}
+
+	expected := `@prefix ex: <http://example.org/> .
+@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+ex:alice 
+	ex:address [ ex:city "Springfield" ; ex:street "Main St" ] ;
+	ex:manager ex:bob ;
+	foaf:knows [ foaf:name "Carol" ], ex:bob ;
+	foaf:name "Alice" .
+ex:bob 
+	ex:manager ex:alice ;
+	foaf:name "Bob" .
+`
+
+	b, err := exConfig.Marshal([]*employee{alice, bob})
+	assert.NoError(t, err, "Marshal function should have returned no error")
+	assert.Equal(t, expected, string(b), "Marshal function should have written the nested resources")
+}
+
+func TestUnmarshalNested(t *testing.T) {
+	data := []byte(`@prefix ex: <http://example.org/> .
+@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+
+ex:alice foaf:name "Alice" ;
+	ex:address [ ex:street "Main St" ; ex:city "Springfield" ] ;
+	ex:manager ex:bob ;
+	foaf:knows [ foaf:name "Carol" ; foaf:knows ex:alice ], ex:bob .
+
+ex:bob foaf:name "Bob" ;
+	ex:manager ex:alice .`)
+
+	var target []*employee
+	err := turtle.Unmarshal(data, &target)
+	assert.NoError(t, err, "Unmarshal function should have returned no error")
+	assert.Equal(t, 2, len(target), "Unmarshal function should have nested the blank nodes")
+
+	alice, bob := target[0], target[1]
+	assert.Equal(t, "Alice", alice.Name, "Unmarshal function should have filled the first resource")
+	assert.Equal(t, address{Street: "Main St", City: "Springfield"}, alice.Address, "Unmarshal function should have filled the nested struct")
+	assert.Equal(t, true, alice.Manager == bob, "Unmarshal function should have linked the resources")
+	assert.Equal(t, true, bob.Manager == alice, "Unmarshal function should have linked the resources in a cycle")
+	assert.Equal(t, 2, len(alice.Friends), "Unmarshal function should have filled the slice of nested resources")
+	assert.Equal(t, "", alice.Friends[0].ID, "Unmarshal function should have left the blank node's subject empty")
+	assert.Equal(t, "Carol", alice.Friends[0].Name, "Unmarshal function should have filled the nested blank node")
+	assert.Equal(t, true, alice.Friends[0].Friends[0] == alice, "Unmarshal function should have linked the blank node back")
+	assert.Equal(t, true, alice.Friends[1] == bob, "Unmarshal function should have shared the linked resource")
+}
+
+func TestMarshalUnmarshalNested(t *testing.T) {
+	carol := &employee{Name: "Carol", Address: address{City: "Shelbyville"}}
+	resources := []*employee{
+		{
+			ID:      "http://example.org/alice",
+			Name:    "Alice",
+			Address: address{Street: "Main St", City: "Springfield"},
+			Friends: []*employee{carol},
+		},
+		{
+			ID:      "http://example.org/bob",
+			Name:    "Bob",
+			Friends: []*employee{carol},
+		},
+	}
+
+	b, err := exConfig.Marshal(resources)
+	assert.NoError(t, err, "Marshal function should have returned no error")
+
+	var target []*employee
+	err = exConfig.Unmarshal(b, &target)
+	assert.NoError(t, err, "Unmarshal function should have returned no error")
+	assert.Equal(t, resources, target, "Unmarshal function should have returned the marshalled nested resources")
+	assert.Equal(t, true, target[0].Friends[0] == target[1].Friends[0], "Unmarshal function should have shared the blank node")
+}
+
+func TestDecoderNested(t *testing.T) {
+	data := `@prefix ex: <http://example.org/> .
+@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+ex:alice foaf:name "Alice" ; ex:address [ ex:city "Springfield" ] ; foaf:knows [ foaf:name "Carol" ] .
+ex:bob foaf:name "Bob" ; ex:address [ ex:city "Shelbyville" ] .
+[ foaf:name "Dave" ] .`
+	expected := []employee{
+		{
+			ID:      "http://example.org/alice",
+			Name:    "Alice",
+			Address: address{City: "Springfield"},
+			Friends: []*employee{{Name: "Carol"}},
+		},
+		{
+			ID:      "http://example.org/bob",
+			Name:    "Bob",
+			Address: address{City: "Shelbyville"},
+		},
+		{
+			Name: "Dave",
+		},
+	}
+
+	d := exConfig.NewDecoder(strings.NewReader(data))
+
+	actual := make([]employee, 0)
+	for d.More() {
+		var target employee
+		err := d.Decode(&target)
+		assert.NoError(t, err, "method Decode should have returned no error")
+		actual = append(actual, target)
+	}
+
+	assert.Equal(t, expected, actual, "decoder should have decoded the nested blank nodes")
+}
+
+func TestMarshalNTriples(t *testing.T) {
+	config := foafConfig
+	config.Format = graph.FormatNTriples
+
+	resources := []person{
+		{ID: "http://example.org/alice", Name: "Alice", Age: 30},
+		{Name: "Nobody"},
+	}
+	expected := `_:b0 <http://xmlns.com/foaf/0.1/name> "Nobody" .
+<http://example.org/alice> <http://xmlns.com/foaf/0.1/age> "30"^^<http://www.w3.org/2001/XMLSchema#integer> .
+<http://example.org/alice> <http://xmlns.com/foaf/0.1/name> "Alice" .
+`
+
+	b, err := config.Marshal(resources)
+	assert.NoError(t, err, "Marshal function should have returned no error")
+	assert.Equal(t, expected, string(b), "Marshal function should have written a triple per line")
+
+	r := ntriples.NewReader(strings.NewReader(string(b)))
+	var triples int
+	for r.Next() {
+		triples++
+	}
+	assert.NoError(t, r.Err(), "N-Triples reader should have read the marshalled data")
+	assert.Equal(t, 3, triples, "N-Triples reader should have read all the triples")
+}
+
+func TestMarshalLinkedBack(t *testing.T) {
+	root := node{ID: "http://example.org/a", Tags: []string{"t1"}}
+	root.Next = &node{ID: "http://example.org/b", Next: &root}
+
+	nodes := []node{{Tags: []string{"t2"}}}
+	nodes[0].Next = &nodes[0]
+
+	testCases := map[string]struct {
+		v        interface{}
+		expected string
+	}{
+		"root_by_value": {
+			v: root,
+			expected: `@prefix ex: <http://example.org/> .
+@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+ex:a 
+	ex:next ex:b ;
+	ex:tags ( "t1" ) .
+ex:b ex:next ex:a .
+`,
+		},
+		"addressable_blank_node": {
+			v: nodes,
+			expected: `@prefix ex: <http://example.org/> .
+@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+_:b0 
+	ex:next _:b0 ;
+	ex:tags ( "t2" ) .
+`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			b, err := exConfig.Marshal(tc.v)
+			assert.NoError(t, err, "Marshal function should have returned no error")
+			assert.Equal(t, tc.expected, string(b), "Marshal function should have written the resource linked back to once")
+		})
+	}
+}
+
+func TestUnmarshalBlankNodeCycles(t *testing.T) {
+	testCases := map[string]struct {
+		data   string
+		length int
+		nodes  int
+	}{
+		"self_reference": {
+			data:   `_:a ex:tags ( "t1" ) ; ex:next _:a .`,
+			length: 1,
+			nodes:  1,
+		},
+		"longer_cycle": {
+			data:   `_:a ex:next _:b . _:b ex:next _:c . _:c ex:next _:a .`,
+			length: 1,
+			nodes:  3,
+		},
+		"cycle_nested_in_resource": {
+			data:   `ex:root ex:next _:a . _:a ex:next _:b . _:b ex:next _:a .`,
+			length: 1,
+			nodes:  3,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var target []*node
+			err := exConfig.Unmarshal([]byte("@prefix ex: <http://example.org/> .\n"+tc.data), &target)
+			assert.NoError(t, err, "Unmarshal function should have returned no error")
+			assert.Equal(t, tc.length, len(target), "Unmarshal function should have returned a single root of the cycle")
+
+			// the cycle leads back to the node it starts at
+			seen := make(map[*node]bool)
+			n := target[0]
+			for n != nil && !seen[n] {
+				seen[n] = true
+				n = n.Next
+			}
+			assert.Equal(t, tc.nodes, len(seen), "Unmarshal function should have linked all the nodes of the cycle")
+			assert.Equal(t, true, n != nil, "Unmarshal function should have closed the cycle")
+		})
+	}
+}
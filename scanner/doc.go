@@ -1,6 +1,13 @@
 // Package scanner implements parsing Turtle data provided as a byte slice
-// and reading it triple by triple. It handles the compact version of Turtle
-// just as the N-triples version where each row corresponds to a single triple.
-// It handles @base and @forms. It ignores comments and labels and data types
-// assigned to object literals.
+// or an io.Reader and reading it triple by triple. It handles the compact
+// version of Turtle just as the N-triples version where each row corresponds
+// to a single triple. It handles @base and @forms. It ignores comments and
+// labels and data types assigned to object literals. It also reads TriG
+// data when set in the options, reporting the name of the graph block
+// each triple belongs to, and the quoted triples and annotations of
+// RDF-star. A quoted triple takes the place of a single term in its
+// N-Triples form.
+// A Handler set in the options is called for the directives, triples,
+// comments, statement ends, blank node property lists, collections and
+// errors as the data is read.
 package scanner
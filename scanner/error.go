@@ -0,0 +1,54 @@
+package scanner
+
+import (
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+)
+
+// Position describes a place in the scanned data.
+type Position struct {
+	// Offset is the number of bytes preceding the place.
+	Offset int
+	// Line is the line number starting at 1.
+	Line int
+	// Column is the character position within the line starting at 1.
+	Column int
+}
+
+func (p Position) advance(data []byte) Position {
+	for len(data) > 0 {
+		r, width := utf8.DecodeRune(data)
+		data = data[width:]
+
+		p.Offset += width
+		if r == runeNewLine {
+			p.Line++
+			p.Column = 1
+			continue
+		}
+		p.Column++
+	}
+
+	return p
+}
+
+// SyntaxError describes a malformed part of the scanned Turtle data.
+// It is returned by Scanner.Err.
+type SyntaxError struct {
+	Position
+	// Token is the offending token. It is empty when the data ended unexpectedly.
+	Token string
+	// Expected describes what was expected in place of the token.
+	Expected string
+}
+
+func (e *SyntaxError) Error() string {
+	token := "end of data"
+	if e.Token != "" {
+		token = strconv.Quote(e.Token)
+	}
+
+	return fmt.Sprintf("syntax error at line %d, column %d (offset %d): unexpected %s, expected %s",
+		e.Line, e.Column, e.Offset, token, e.Expected)
+}
@@ -0,0 +1,266 @@
+package scanner
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nvkp/turtle/assert"
+)
+
+var syntaxErrorTestCases = map[string]struct {
+	data     string
+	trig     bool
+	triples  int
+	expected SyntaxError
+}{
+	"closing_bracket_without_list": {
+		data:    "<a> <b> <c> .\n<d> <e> ] .",
+		triples: 1,
+		expected: SyntaxError{
+			Position: Position{Offset: 22, Line: 2, Column: 9},
+			Token:    "]",
+			Expected: "object",
+		},
+	},
+	"closing_parenthesis_without_collection": {
+		data: "<a> <b> <c> ) .",
+		expected: SyntaxError{
+			Position: Position{Offset: 12, Line: 1, Column: 13},
+			Token:    ")",
+			Expected: `";", "," or "."`,
+		},
+		triples: 1,
+	},
+	"prefix_without_value": {
+		data: "@prefix foaf:",
+		expected: SyntaxError{
+			Position: Position{Offset: 13, Line: 1, Column: 14},
+			Expected: "IRI",
+		},
+	},
+	"prefix_without_colon": {
+		data: "@prefix foaf <http://xmlns.com/foaf/0.1/> .",
+		expected: SyntaxError{
+			Position: Position{Offset: 8, Line: 1, Column: 9},
+			Token:    "foaf",
+			Expected: "prefix name ending with a colon",
+		},
+	},
+	"base_not_iri": {
+		data: "@base example .",
+		expected: SyntaxError{
+			Position: Position{Offset: 6, Line: 1, Column: 7},
+			Token:    "example",
+			Expected: "IRI",
+		},
+	},
+	"missing_object": {
+		data: "<a> <b> .",
+		expected: SyntaxError{
+			Position: Position{Offset: 8, Line: 1, Column: 9},
+			Token:    ".",
+			Expected: "object",
+		},
+	},
+	"missing_predicate_at_end": {
+		data: "<a> <b> <c> ;\n\t<d>",
+		expected: SyntaxError{
+			Position: Position{Offset: 18, Line: 2, Column: 5},
+			Expected: "object",
+		},
+		triples: 1,
+	},
+	"semicolon_without_subject": {
+		data: "# comment\n; <b> <c> .",
+		expected: SyntaxError{
+			Position: Position{Offset: 10, Line: 2, Column: 1},
+			Token:    ";",
+			Expected: "subject",
+		},
+	},
+	"unclosed_blank_node_list": {
+		data: "<a> <b> [ <c> <d> .",
+		expected: SyntaxError{
+			Position: Position{Offset: 18, Line: 1, Column: 19},
+			Token:    ".",
+			Expected: `"]"`,
+		},
+		triples: 1,
+	},
+	"unclosed_collection": {
+		data: "<a> <b> ( <c> <d>",
+		expected: SyntaxError{
+			Position: Position{Offset: 17, Line: 1, Column: 18},
+			Expected: `")"`,
+		},
+	},
+	"unterminated_literal": {
+		data: `<a> <b> "Человек-паук`,
+		expected: SyntaxError{
+			Position: Position{Offset: 8, Line: 1, Column: 9},
+			Token:    `"Человек-паук`,
+			Expected: `"\""`,
+		},
+	},
+	"unterminated_literal_escaped_quote": {
+		data: `<a> <b> "Spider-Man\"`,
+		expected: SyntaxError{
+			Position: Position{Offset: 8, Line: 1, Column: 9},
+			Token:    `"Spider-Man\"`,
+			Expected: `"\""`,
+		},
+	},
+	"unclosed_graph_block": {
+		data:    "<g> { <a> <b> <c> .",
+		trig:    true,
+		triples: 1,
+		expected: SyntaxError{
+			Position: Position{Offset: 19, Line: 1, Column: 20},
+			Expected: `"}"`,
+		},
+	},
+	"nested_graph_block": {
+		data: "<g> { <h> { <a> <b> <c> } }",
+		trig: true,
+		expected: SyntaxError{
+			Position: Position{Offset: 10, Line: 1, Column: 11},
+			Token:    "{",
+			Expected: "predicate",
+		},
+	},
+	"graph_keyword_without_block": {
+		data: "GRAPH <g> <a> <b> <c> .",
+		trig: true,
+		expected: SyntaxError{
+			Position: Position{Offset: 10, Line: 1, Column: 11},
+			Token:    "<a>",
+			Expected: `"{"`,
+		},
+	},
+	"graph_keyword_without_name": {
+		data: "GRAPH { <a> <b> <c> }",
+		trig: true,
+		expected: SyntaxError{
+			Position: Position{Offset: 6, Line: 1, Column: 7},
+			Token:    "{",
+			Expected: "graph name",
+		},
+	},
+	"graph_block_in_turtle": {
+		data: "<g> { <a> <b> <c> }",
+		expected: SyntaxError{
+			Position: Position{Offset: 4, Line: 1, Column: 5},
+			Token:    "{",
+			Expected: "predicate",
+		},
+	},
+	"closing_brace_without_block": {
+		data:    "<a> <b> <c> . }",
+		trig:    true,
+		triples: 1,
+		expected: SyntaxError{
+			Position: Position{Offset: 14, Line: 1, Column: 15},
+			Token:    "}",
+			Expected: "subject",
+		},
+	},
+	"quoted_predicate": {
+		data: "<a> << <b> <c> <d> >> <e> .",
+		expected: SyntaxError{
+			Position: Position{Offset: 4, Line: 1, Column: 5},
+			Token:    "<<",
+			Expected: "predicate",
+		},
+	},
+	"quoted_triple_with_four_terms": {
+		data: "<< <a> <b> <c> <d> >> <e> <f> .",
+		expected: SyntaxError{
+			Position: Position{Offset: 15, Line: 1, Column: 16},
+			Token:    "<d>",
+			Expected: `">>"`,
+		},
+	},
+	"quoted_triple_with_two_terms": {
+		data: "<< <a> <b> >> <e> <f> .",
+		expected: SyntaxError{
+			Position: Position{Offset: 11, Line: 1, Column: 12},
+			Token:    ">>",
+			Expected: "object",
+		},
+	},
+	"unclosed_quoted_triple": {
+		data: "<a> <b> << <c> <d> <e>",
+		expected: SyntaxError{
+			Position: Position{Offset: 22, Line: 1, Column: 23},
+			Expected: `">>"`,
+		},
+	},
+	"blank_node_list_in_quoted_triple": {
+		data: "<< <a> <b> [ <c> <d> ] >> <e> <f> .",
+		expected: SyntaxError{
+			Position: Position{Offset: 13, Line: 1, Column: 14},
+			Token:    "<c>",
+			Expected: `"]"`,
+		},
+	},
+	"annotation_without_triple": {
+		data: "<a> {| <b> <c> |} .",
+		expected: SyntaxError{
+			Position: Position{Offset: 4, Line: 1, Column: 5},
+			Token:    "{|",
+			Expected: "predicate",
+		},
+	},
+	"unclosed_annotation": {
+		data:    "<a> <b> <c> {| <d> <e> .",
+		triples: 2,
+		expected: SyntaxError{
+			Position: Position{Offset: 23, Line: 1, Column: 24},
+			Token:    ".",
+			Expected: `"|}"`,
+		},
+	},
+	"unterminated_iri": {
+		data: "<a> <b> <http://example.org/c",
+		expected: SyntaxError{
+			Position: Position{Offset: 8, Line: 1, Column: 9},
+			Token:    "<http://example.org/c",
+			Expected: `">"`,
+		},
+	},
+}
+
+func TestSyntaxError(t *testing.T) {
+	for name, tc := range syntaxErrorTestCases {
+		t.Run(name, func(t *testing.T) {
+			s := NewWithOptions([]byte(tc.data), Options{TriG: tc.trig})
+
+			var triples int
+			for s.Next() {
+				triples++
+			}
+
+			assert.Equal(t, tc.triples, triples, "scanner should have returned the triples preceding the error")
+
+			var syntaxErr *SyntaxError
+			if !errors.As(s.Err(), &syntaxErr) {
+				t.Fatalf("expected a syntax error, got: %v", s.Err())
+			}
+
+			assert.Equal(t, tc.expected, *syntaxErr, "scanner should have returned a correct syntax error")
+			assert.Equal(t, false, s.Next(), "scanner should not continue after an error")
+		})
+	}
+}
+
+func TestSyntaxErrorMessage(t *testing.T) {
+	err := &SyntaxError{
+		Position: Position{Offset: 8, Line: 1, Column: 9},
+		Token:    ".",
+		Expected: "object",
+	}
+	assert.Equal(t, `syntax error at line 1, column 9 (offset 8): unexpected ".", expected object`, err.Error(), "error message should describe the error")
+
+	err.Token = ""
+	assert.Equal(t, `syntax error at line 1, column 9 (offset 8): unexpected end of data, expected object`, err.Error(), "error message should describe the error")
+}
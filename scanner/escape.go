@@ -0,0 +1,94 @@
+package scanner
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/nvkp/turtle/rdf"
+)
+
+// localEscapes lists the characters that can be escaped
+// by a backslash in the local part of a prefixed name.
+const localEscapes = "_~.-!$&'()*+,;=/?#@%"
+
+// unescapeString replaces the escape sequences of a string literal, e.g.
+// \n, \" or \u00E9, with the characters they stand for. Sequences that
+// are not valid are kept as they were written.
+func unescapeString(str string) string {
+	return unescape(str, rdf.UnescapeChar)
+}
+
+// unescapeIRI replaces the \u and \U escape sequences of an IRI
+// with the characters they stand for.
+func unescapeIRI(str string) string {
+	return unescape(str, func(byte) (byte, bool) {
+		return 0, false
+	})
+}
+
+// unescapeLocal removes the backslashes escaping the reserved characters
+// in the local part of a prefixed name, e.g. ex:a\.b becomes ex:a.b.
+func unescapeLocal(str string) string {
+	return unescape(str, func(c byte) (byte, bool) {
+		return c, strings.IndexByte(localEscapes, c) != -1
+	})
+}
+
+func unescape(str string, single func(byte) (byte, bool)) string {
+	if !strings.Contains(str, `\`) {
+		return str
+	}
+
+	var b strings.Builder
+	b.Grow(len(str))
+
+	for i := 0; i < len(str); i++ {
+		if str[i] != runeBackslash || i+1 == len(str) {
+			b.WriteByte(str[i])
+			continue
+		}
+
+		c := str[i+1]
+		if r, ok := single(c); ok {
+			b.WriteByte(r)
+			i++
+			continue
+		}
+
+		if r, n, ok := unescapeUnicode(str[i+1:]); ok {
+			b.WriteRune(r)
+			i += n
+			continue
+		}
+
+		b.WriteByte(str[i])
+	}
+
+	return b.String()
+}
+
+// unescapeUnicode decodes the code point of an escape sequence in the form
+// uXXXX or UXXXXXXXX, returning the number of bytes it occupies.
+func unescapeUnicode(str string) (rune, int, bool) {
+	var n int
+	switch str[0] {
+	case 'u':
+		n = 5
+	case 'U':
+		n = 9
+	default:
+		return 0, 0, false
+	}
+
+	if len(str) < n {
+		return 0, 0, false
+	}
+
+	code, err := strconv.ParseUint(str[1:n], 16, 32)
+	if err != nil || !utf8.ValidRune(rune(code)) {
+		return 0, 0, false
+	}
+
+	return rune(code), n, true
+}
@@ -0,0 +1,72 @@
+package scanner
+
+// Handler is called by the scanner as it reads the data, so that the
+// directives, the comments and the structure of the data can be followed
+// besides the triples returned by Next. Every method but OnError gets
+// the position of the token it is called for. See Options.Handler.
+type Handler interface {
+	// OnPrefix is called for every prefix declaration with the name
+	// of the prefix without the colon and the IRI it stands for.
+	OnPrefix(pos Position, prefix string, iri string)
+	// OnBase is called for every base declaration with the new base.
+	OnBase(pos Position, iri string)
+	// OnTriple is called for every triple as soon as it is read, before
+	// Next returns it, with the name of its graph, an empty string for
+	// the default graph. The triple is the one of TripleWithAnnotations.
+	OnTriple(pos Position, t [6]string, graph string)
+	// OnComment is called for every comment with the text following
+	// the number sign up to the end of the line.
+	OnComment(pos Position, text string)
+	// OnStatementEnd is called when the triples of a statement end,
+	// either by the full stop or by the end of a graph block.
+	OnStatementEnd(pos Position)
+	// OnBlankNodeListStart is called at the opening bracket of a blank
+	// node property list with the blank node the list stands for.
+	OnBlankNodeListStart(pos Position, blankNode string)
+	// OnBlankNodeListEnd is called at the closing bracket of a blank
+	// node property list after the triples of its predicates.
+	OnBlankNodeListEnd(pos Position, blankNode string)
+	// OnCollectionStart is called at the opening parenthesis of a collection.
+	OnCollectionStart(pos Position)
+	// OnCollectionEnd is called at the closing parenthesis of a collection
+	// after the triples of its items with the node the collection stands
+	// for, its first blank node or rdf:nil for an empty collection.
+	OnCollectionEnd(pos Position, head string)
+	// OnError is called with the error the scanner stopped at,
+	// the one returned by Err.
+	OnError(err error)
+}
+
+// NopHandler implements Handler by doing nothing. It can be embedded
+// in the handlers that need only some of the methods.
+type NopHandler struct{}
+
+// OnPrefix does nothing.
+func (NopHandler) OnPrefix(Position, string, string) {}
+
+// OnBase does nothing.
+func (NopHandler) OnBase(Position, string) {}
+
+// OnTriple does nothing.
+func (NopHandler) OnTriple(Position, [6]string, string) {}
+
+// OnComment does nothing.
+func (NopHandler) OnComment(Position, string) {}
+
+// OnStatementEnd does nothing.
+func (NopHandler) OnStatementEnd(Position) {}
+
+// OnBlankNodeListStart does nothing.
+func (NopHandler) OnBlankNodeListStart(Position, string) {}
+
+// OnBlankNodeListEnd does nothing.
+func (NopHandler) OnBlankNodeListEnd(Position, string) {}
+
+// OnCollectionStart does nothing.
+func (NopHandler) OnCollectionStart(Position) {}
+
+// OnCollectionEnd does nothing.
+func (NopHandler) OnCollectionEnd(Position, string) {}
+
+// OnError does nothing.
+func (NopHandler) OnError(error) {}
@@ -0,0 +1,156 @@
+package scanner_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"testing/iotest"
+
+	"github.com/nvkp/turtle/assert"
+	"github.com/nvkp/turtle/scanner"
+)
+
+// recorder writes down every call of the handler on a line of its own.
+type recorder struct {
+	events []string
+}
+
+func (r *recorder) OnPrefix(pos scanner.Position, prefix string, iri string) {
+	r.events = append(r.events, fmt.Sprintf("%d:%d prefix %s %s", pos.Line, pos.Column, prefix, iri))
+}
+
+func (r *recorder) OnBase(pos scanner.Position, iri string) {
+	r.events = append(r.events, fmt.Sprintf("%d:%d base %s", pos.Line, pos.Column, iri))
+}
+
+func (r *recorder) OnTriple(pos scanner.Position, t [6]string, graph string) {
+	r.events = append(r.events, fmt.Sprintf("%d:%d triple %s %s %s %s", pos.Line, pos.Column, t[0], t[1], t[2], graph))
+}
+
+func (r *recorder) OnComment(pos scanner.Position, text string) {
+	r.events = append(r.events, fmt.Sprintf("%d:%d comment %s", pos.Line, pos.Column, text))
+}
+
+func (r *recorder) OnStatementEnd(pos scanner.Position) {
+	r.events = append(r.events, fmt.Sprintf("%d:%d end", pos.Line, pos.Column))
+}
+
+func (r *recorder) OnBlankNodeListStart(pos scanner.Position, blankNode string) {
+	r.events = append(r.events, fmt.Sprintf("%d:%d list %s", pos.Line, pos.Column, blankNode))
+}
+
+func (r *recorder) OnBlankNodeListEnd(pos scanner.Position, blankNode string) {
+	r.events = append(r.events, fmt.Sprintf("%d:%d list end %s", pos.Line, pos.Column, blankNode))
+}
+
+func (r *recorder) OnCollectionStart(pos scanner.Position) {
+	r.events = append(r.events, fmt.Sprintf("%d:%d collection", pos.Line, pos.Column))
+}
+
+func (r *recorder) OnCollectionEnd(pos scanner.Position, head string) {
+	r.events = append(r.events, fmt.Sprintf("%d:%d collection end %s", pos.Line, pos.Column, head))
+}
+
+func (r *recorder) OnError(err error) {
+	r.events = append(r.events, fmt.Sprintf("error %v", err))
+}
+
+var handlerTestCases = map[string]struct {
+	data     string
+	trig     bool
+	expected []string
+}{
+	"directives_and_comments": {
+		data: "# people\r\n@base <http://example.org/> .\nPREFIX foaf: <http://xmlns.com/foaf/0.1/>\n" +
+			"<alice> foaf:knows [ foaf:name \"Bob # not a comment\" ] . # the end",
+		expected: []string{
+			"1:1 comment  people",
+			"2:1 base http://example.org/",
+			"3:1 prefix foaf http://xmlns.com/foaf/0.1/",
+			"4:20 list _:b0",
+			"4:32 triple _:b0 http://xmlns.com/foaf/0.1/name Bob # not a comment ",
+			"4:54 list end _:b0",
+			"4:54 triple http://example.org/alice http://xmlns.com/foaf/0.1/knows _:b0 ",
+			"4:56 end",
+			"4:58 comment  the end",
+		},
+	},
+	"collection": {
+		data: "<a> <b> ( <c> ) .",
+		expected: []string{
+			"1:9 collection",
+			"1:15 triple _:b0 http://www.w3.org/1999/02/22-rdf-syntax-ns#first c ",
+			"1:15 triple _:b0 http://www.w3.org/1999/02/22-rdf-syntax-ns#rest http://www.w3.org/1999/02/22-rdf-syntax-ns#nil ",
+			"1:15 collection end _:b0",
+			"1:15 triple a b _:b0 ",
+			"1:17 end",
+		},
+	},
+	"nested_lists_and_collections": {
+		data: "<a> <b> ( [ <c> <d> ] () ) .\n<< [] <e> <f> >> <g> <h> .",
+		expected: []string{
+			"1:9 collection",
+			"1:11 list _:b0",
+			"1:17 triple _:b0 c d ",
+			"1:21 list end _:b0",
+			"1:23 collection",
+			"1:24 collection end http://www.w3.org/1999/02/22-rdf-syntax-ns#nil",
+			"1:26 triple _:b1 http://www.w3.org/1999/02/22-rdf-syntax-ns#first _:b0 ",
+			"1:26 triple _:b1 http://www.w3.org/1999/02/22-rdf-syntax-ns#rest _:b2 ",
+			"1:26 triple _:b2 http://www.w3.org/1999/02/22-rdf-syntax-ns#first http://www.w3.org/1999/02/22-rdf-syntax-ns#nil ",
+			"1:26 triple _:b2 http://www.w3.org/1999/02/22-rdf-syntax-ns#rest http://www.w3.org/1999/02/22-rdf-syntax-ns#nil ",
+			"1:26 collection end _:b1",
+			"1:26 triple a b _:b1 ",
+			"1:28 end",
+			"2:4 list _:b3",
+			"2:5 list end _:b3",
+			"2:22 triple << _:b3 <e> <f> >> g h ",
+			"2:26 end",
+		},
+	},
+	"graph_block": {
+		data: "<g> { <a> <b> <c> }\n<d> <e> <f> .",
+		trig: true,
+		expected: []string{
+			"1:15 triple a b c g",
+			"1:19 end",
+			"2:9 triple d e f ",
+			"2:13 end",
+		},
+	},
+	"error": {
+		data: "<a> <b> <c> .\n<d> ; <e> .",
+		expected: []string{
+			"1:9 triple a b c ",
+			"1:13 end",
+			`error syntax error at line 2, column 5 (offset 18): unexpected ";", expected predicate`,
+		},
+	},
+}
+
+func TestHandler(t *testing.T) {
+	for name, tc := range handlerTestCases {
+		t.Run(name, func(t *testing.T) {
+			r := &recorder{}
+			s := scanner.NewReaderWithOptions(iotest.OneByteReader(bytes.NewReader([]byte(tc.data))), scanner.Options{Handler: r, TriG: tc.trig})
+			for s.Next() {
+			}
+
+			assert.Equal(t, tc.expected, r.events, "scanner should have called the handler in order of the data")
+		})
+	}
+}
+
+func TestNopHandler(t *testing.T) {
+	h := struct{ scanner.NopHandler }{}
+
+	s := scanner.NewWithOptions([]byte("<a> <b> <c> . # comment"), scanner.Options{Handler: h})
+
+	var count int
+	for s.Next() {
+		count++
+	}
+
+	assert.NoError(t, s.Err(), "scanner should have returned no error")
+	assert.Equal(t, 1, count, "scanner should have returned the triples with a handler doing nothing")
+}
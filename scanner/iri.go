@@ -1,5 +1,7 @@
 package scanner
 
+import "strings"
+
 const (
 	rdfTypeIRI     = "http://www.w3.org/1999/02/22-rdf-syntax-ns#type"
 	rdfFirst       = "http://www.w3.org/1999/02/22-rdf-syntax-ns#first"
@@ -7,3 +9,181 @@ const (
 	rdfNil         = "http://www.w3.org/1999/02/22-rdf-syntax-ns#nil"
 	rdfNilInTurtle = "<http://www.w3.org/1999/02/22-rdf-syntax-ns#nil>"
 )
+
+// reference holds the components of an IRI reference
+// as described in RFC 3986, section 3.
+type reference struct {
+	scheme       string
+	authority    string
+	path         string
+	query        string
+	fragment     string
+	hasScheme    bool
+	hasAuthority bool
+	hasQuery     bool
+	hasFragment  bool
+}
+
+// parseReference splits the IRI reference into its components
+// the way the regular expression of RFC 3986, appendix B does.
+func parseReference(iri string) reference {
+	var r reference
+
+	if i := strings.IndexByte(iri, '#'); i != -1 {
+		r.fragment, r.hasFragment = iri[i+1:], true
+		iri = iri[:i]
+	}
+
+	if i := strings.IndexByte(iri, '?'); i != -1 {
+		r.query, r.hasQuery = iri[i+1:], true
+		iri = iri[:i]
+	}
+
+	if i := strings.IndexByte(iri, ':'); i > 0 && !strings.ContainsAny(iri[:i], "/") {
+		r.scheme, r.hasScheme = iri[:i], true
+		iri = iri[i+1:]
+	}
+
+	if strings.HasPrefix(iri, "//") {
+		iri = iri[2:]
+		i := strings.IndexByte(iri, '/')
+		if i == -1 {
+			i = len(iri)
+		}
+		r.authority, r.hasAuthority = iri[:i], true
+		iri = iri[i:]
+	}
+
+	r.path = iri
+	return r
+}
+
+// String recomposes the components as described in RFC 3986, section 5.3.
+func (r reference) String() string {
+	var b strings.Builder
+
+	if r.hasScheme {
+		b.WriteString(r.scheme)
+		b.WriteByte(':')
+	}
+
+	if r.hasAuthority {
+		b.WriteString("//")
+		b.WriteString(r.authority)
+	}
+
+	b.WriteString(r.path)
+
+	if r.hasQuery {
+		b.WriteByte('?')
+		b.WriteString(r.query)
+	}
+
+	if r.hasFragment {
+		b.WriteByte('#')
+		b.WriteString(r.fragment)
+	}
+
+	return b.String()
+}
+
+// resolveIRI resolves the IRI reference against the base IRI
+// as described in RFC 3986, section 5.2.
+func resolveIRI(base string, ref string) string {
+	r := parseReference(ref)
+	if r.hasScheme {
+		r.path = removeDotSegments(r.path)
+		return r.String()
+	}
+
+	b := parseReference(base)
+	t := reference{
+		scheme:      b.scheme,
+		hasScheme:   b.hasScheme,
+		fragment:    r.fragment,
+		hasFragment: r.hasFragment,
+	}
+
+	switch {
+	case r.hasAuthority:
+		t.authority, t.hasAuthority = r.authority, true
+		t.path = removeDotSegments(r.path)
+		t.query, t.hasQuery = r.query, r.hasQuery
+		return t.String()
+	case r.path == "":
+		t.path = b.path
+		if r.hasQuery {
+			t.query, t.hasQuery = r.query, true
+		} else {
+			t.query, t.hasQuery = b.query, b.hasQuery
+		}
+	case strings.HasPrefix(r.path, "/"):
+		t.path = removeDotSegments(r.path)
+		t.query, t.hasQuery = r.query, r.hasQuery
+	default:
+		t.path = removeDotSegments(mergePaths(b, r.path))
+		t.query, t.hasQuery = r.query, r.hasQuery
+	}
+
+	t.authority, t.hasAuthority = b.authority, b.hasAuthority
+	return t.String()
+}
+
+// mergePaths appends the relative path to all but the last segment
+// of the base path as described in RFC 3986, section 5.2.3.
+func mergePaths(base reference, path string) string {
+	if base.hasAuthority && base.path == "" {
+		return "/" + path
+	}
+
+	return base.path[:strings.LastIndexByte(base.path, '/')+1] + path
+}
+
+// removeDotSegments interprets and removes the "." and ".." segments
+// of the path as described in RFC 3986, section 5.2.4.
+func removeDotSegments(path string) string {
+	if !strings.Contains(path, ".") {
+		return path
+	}
+
+	var out []string
+	for len(path) > 0 {
+		switch {
+		case strings.HasPrefix(path, "../"):
+			path = path[3:]
+		case strings.HasPrefix(path, "./"):
+			path = path[2:]
+		case strings.HasPrefix(path, "/./"):
+			path = path[2:]
+		case path == "/.":
+			path = "/"
+		case strings.HasPrefix(path, "/../"):
+			path = path[3:]
+			out = removeLastSegment(out)
+		case path == "/..":
+			path = "/"
+			out = removeLastSegment(out)
+		case path == "." || path == "..":
+			path = ""
+		default:
+			// move the first segment including its leading slash
+			i := strings.IndexByte(path[1:], '/')
+			if i == -1 {
+				i = len(path)
+			} else {
+				i++
+			}
+			out = append(out, path[:i])
+			path = path[i:]
+		}
+	}
+
+	return strings.Join(out, "")
+}
+
+func removeLastSegment(segments []string) []string {
+	if len(segments) == 0 {
+		return segments
+	}
+	return segments[:len(segments)-1]
+}
@@ -0,0 +1,116 @@
+package scanner
+
+import (
+	"testing"
+
+	"github.com/nvkp/turtle/assert"
+)
+
+// the examples of RFC 3986, section 5.4 and of the W3C test cases
+// of the Turtle test suite resolving against the same base
+var resolveTestCases = map[string]string{
+	// normal examples
+	"g:h":     "g:h",
+	"g":       "http://a/b/c/g",
+	"./g":     "http://a/b/c/g",
+	"g/":      "http://a/b/c/g/",
+	"/g":      "http://a/g",
+	"//g":     "http://g",
+	"?y":      "http://a/b/c/d;p?y",
+	"g?y":     "http://a/b/c/g?y",
+	"#s":      "http://a/b/c/d;p?q#s",
+	"g#s":     "http://a/b/c/g#s",
+	"g?y#s":   "http://a/b/c/g?y#s",
+	";x":      "http://a/b/c/;x",
+	"g;x":     "http://a/b/c/g;x",
+	"g;x?y#s": "http://a/b/c/g;x?y#s",
+	"":        "http://a/b/c/d;p?q",
+	".":       "http://a/b/c/",
+	"./":      "http://a/b/c/",
+	"..":      "http://a/b/",
+	"../":     "http://a/b/",
+	"../g":    "http://a/b/g",
+	"../..":   "http://a/",
+	"../../":  "http://a/",
+	"../../g": "http://a/g",
+	// abnormal examples
+	"../../../g":    "http://a/g",
+	"../../../../g": "http://a/g",
+	"/./g":          "http://a/g",
+	"/../g":         "http://a/g",
+	"g.":            "http://a/b/c/g.",
+	".g":            "http://a/b/c/.g",
+	"g..":           "http://a/b/c/g..",
+	"..g":           "http://a/b/c/..g",
+	"./../g":        "http://a/b/g",
+	"./g/.":         "http://a/b/c/g/",
+	"g/./h":         "http://a/b/c/g/h",
+	"g/../h":        "http://a/b/c/h",
+	"g;x=1/./y":     "http://a/b/c/g;x=1/y",
+	"g;x=1/../y":    "http://a/b/c/y",
+	"g?y/./x":       "http://a/b/c/g?y/./x",
+	"g?y/../x":      "http://a/b/c/g?y/../x",
+	"g#s/./x":       "http://a/b/c/g#s/./x",
+	"g#s/../x":      "http://a/b/c/g#s/../x",
+	"http:g":        "http:g",
+	// additional cases of the W3C test suite
+	"http://a/b/c/../d": "http://a/b/d",
+	"g/h/../../..":      "http://a/b/",
+	"?":                 "http://a/b/c/d;p?",
+	"#":                 "http://a/b/c/d;p?q#",
+}
+
+func TestResolveIRI(t *testing.T) {
+	for ref, expected := range resolveTestCases {
+		t.Run(ref, func(t *testing.T) {
+			actual := resolveIRI("http://a/b/c/d;p?q", ref)
+			assert.Equal(t, expected, actual, "function should have resolved the reference correctly")
+		})
+	}
+}
+
+var resolveBaseTestCases = map[string]struct {
+	base     string
+	ref      string
+	expected string
+}{
+	"base_without_path": {
+		base:     "http://example.org",
+		ref:      "person",
+		expected: "http://example.org/person",
+	},
+	"base_without_trailing_slash": {
+		base:     "http://example.org/stats",
+		ref:      "census",
+		expected: "http://example.org/census",
+	},
+	"base_with_fragment": {
+		base:     "http://example.org/stats#",
+		ref:      "#census",
+		expected: "http://example.org/stats#census",
+	},
+	"empty_reference_drops_fragment": {
+		base:     "http://example.org/stats#census",
+		ref:      "",
+		expected: "http://example.org/stats",
+	},
+	"unicode": {
+		base:     "http://example.org/Česko/",
+		ref:      "../Praha?ř=ů",
+		expected: "http://example.org/Praha?ř=ů",
+	},
+	"urn": {
+		base:     "urn:isbn:0451450523",
+		ref:      "#chapter",
+		expected: "urn:isbn:0451450523#chapter",
+	},
+}
+
+func TestResolveIRIBase(t *testing.T) {
+	for name, tc := range resolveBaseTestCases {
+		t.Run(name, func(t *testing.T) {
+			actual := resolveIRI(tc.base, tc.ref)
+			assert.Equal(t, tc.expected, actual, "function should have resolved the reference correctly")
+		})
+	}
+}
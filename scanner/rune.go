@@ -15,6 +15,9 @@ const (
 	runeRightSquareBracket = '\u005D' // ]
 	runeOpeningParenthesis = '\u0028' // (
 	runeClosingParenthesis = '\u0029' // )
+	runeLeftCurlyBracket   = '\u007B' // {
+	runeRightCurlyBracket  = '\u007D' // }
+	runeVerticalLine       = '\u007C' // |
 	runeUpperCaseE         = '\u0045' // E
 	runeLowerCaseE         = '\u0065' // e
 	runeHyphen             = '\u002D' // -
@@ -30,8 +33,32 @@ var keyCharacters = []rune{
 	runeRightSquareBracket,
 	runeOpeningParenthesis,
 	runeClosingParenthesis,
+	runeLeftCurlyBracket,
+	runeRightCurlyBracket,
 }
 
+// nameDelimiters end a name besides the spaces and the key characters.
+var nameDelimiters = []rune{
+	runeNumber,
+	runeQuotation,
+	runeApostrophe,
+	runeLessThan,
+	runeGreaterThan,
+	runeCaret,
+	runeVerticalLine,
+}
+
+// starCharacters start the delimiters of quoted triples and annotations.
+var starCharacters = []rune{
+	runeLessThan,
+	runeGreaterThan,
+	runeLeftCurlyBracket,
+	runeVerticalLine,
+}
+
+// starDelimiters enclose quoted triples and annotations.
+var starDelimiters = []string{"<<", ">>", "{|", "|}"}
+
 var numberCharacters = []rune{
 	runeUpperCaseE,
 	runeLowerCaseE,
@@ -2,9 +2,10 @@ package scanner
 
 import (
 	"fmt"
-	"net/url"
 	"regexp"
 	"strings"
+
+	"github.com/nvkp/turtle/rdf"
 )
 
 const (
@@ -14,36 +15,26 @@ const (
 
 var numberRegex = regexp.MustCompile(`^[-0-9]+(?:\.[0-9]+)?`)
 
-func expandPrefix(token string, value string) string {
-	i := strings.Index(token, ":")
-	if len(token) <= i+1 {
-		return ""
-	} else {
-		if len(token) > i+2 && (token[i+1] == '/' || token[i+1] == '#') && value[len(value)-1] == token[i+1] {
-			// if characters exist for both, trim token since we were going to do that anyway
-			token = token[i+2:]
-		} else if !(token[i+1] == '/' || token[i+1] == '#') && !(value[len(value)-1] == '/' || value[len(value)-1] == '#') {
-			// inverse, no characters; we need to add a slash
-			token = fmt.Sprintf("/%s", token[i+1:])
-		} else {
-			// otherwise, just trim the colon
-			token = token[i+1:]
-		}
-
-		return fmt.Sprintf("<%s%s>", value, token)
+func (s *Scanner) sanitize(token string) (string, string, string, string) {
+	// the quoted triples are read in the N-Triples form already
+	if rdf.IsQuotedTriple(token) {
+		return token, "", "", typeTriple
 	}
-}
 
-func (s *Scanner) sanitize(token string) (string, string, string, string) {
 	var label, datatype string
 	typ := "literal"
+	var prefixed bool
 
 	// apply the stored prefixes
-	for prefix, value := range s.prefixes {
-		if strings.HasPrefix(token, fmt.Sprintf("%s:", prefix)) {
-			token = expandPrefix(token, value)
-			typ = "iri"
-			break
+	if !s.options.Verbatim {
+		for prefix, value := range s.prefixes {
+			if strings.HasPrefix(token, fmt.Sprintf("%s:", prefix)) {
+				// the local name is simply appended to the namespace
+				token = fmt.Sprintf("<%s%s>", value, unescapeLocal(token)[len(prefix)+1:])
+				typ = "iri"
+				prefixed = true
+				break
+			}
 		}
 	}
 
@@ -51,42 +42,24 @@ func (s *Scanner) sanitize(token string) (string, string, string, string) {
 	if strings.HasPrefix(token, "<") {
 		typ = "iri"
 		token = trim(token)
-		// short path for easy ones
-		if (token == "." || token == "/") && s.base != "" {
-			token = s.base
-		} else {
-			u, _ := url.Parse(token)
-			if u == nil || u.Host == "" && s.base != "" {
-				// special case for blank anchors
-				if s.base[len(s.base)-1] == '#' && token[0] == '#' {
-					token = s.base + token[1:]
-				} else {
-					b, err := url.Parse(s.base)
-					if err == nil {
-						if token[0] == '#' {
-							// if we have # on the token side, just append the token
-							token = b.String() + token
-						} else {
-							t := b.JoinPath(token)
-							if t.String() == b.String() {
-								// preserve the original form (no slash possibly, String call appends one on domains)
-								token = s.base
-							} else {
-								token = t.String()
-							}
-						}
-					}
-				}
+		// the prefixed names are already expanded to absolute IRIs
+		if !prefixed {
+			token = unescapeIRI(token)
+			if !s.options.Verbatim {
+				token = s.resolve(token)
 			}
 		}
-	} else if strings.HasPrefix(token, `"`) || strings.HasPrefix(token, "-") || numberRegex.MatchString(token) {
+	} else if token == "true" || token == "false" || regexNumeric.MatchString(token) {
+		// the unquoted literals have the datatype of their form
+		return token, label, shorthandDatatype(token), "literal"
+	} else if strings.HasPrefix(token, `"`) || strings.HasPrefix(token, "'") || strings.HasPrefix(token, "-") || numberRegex.MatchString(token) {
 		typ = "literal"
 
 		// extract data type suffix
 		lastDataTypeIndex := lastIndex(token, dataTypeDelimiter)
 		if lastDataTypeIndex != -1 {
 			// Split the string into two parts
-			datatype = token[lastDataTypeIndex+len(dataTypeDelimiter):]
+			datatype = s.sanitizeDatatype(token[lastDataTypeIndex+len(dataTypeDelimiter):])
 			token = token[:lastDataTypeIndex]
 		}
 
@@ -94,9 +67,14 @@ func (s *Scanner) sanitize(token string) (string, string, string, string) {
 		lastLabelIndex := lastIndex(token, labelDelimiter)
 		if lastLabelIndex != -1 {
 			// Split the string into two parts
-			label = token[lastLabelIndex+len(labelDelimiter):]
+			// the strict mode has failed on the invalid tags already
+			label, _ = rdf.NormalizeLanguageTag(token[lastLabelIndex+len(labelDelimiter):])
 			token = token[:lastLabelIndex]
 		}
+
+		// the escape sequences are replaced only after trimming the quotes
+		// so that the escaped ones are kept as part of the literal
+		return unescapeString(trim(token)), label, datatype, typ
 	} else {
 		typ = "iri"
 
@@ -110,6 +88,56 @@ func (s *Scanner) sanitize(token string) (string, string, string, string) {
 	return trim(token), label, datatype, typ
 }
 
+// sanitizeDatatype expands the datatype of a literal to an absolute IRI
+// the same way as the other IRIs. A datatype with an undeclared prefix
+// is kept as it was written.
+func (s *Scanner) sanitizeDatatype(datatype string) string {
+	if strings.HasPrefix(datatype, "<") {
+		datatype = unescapeIRI(trim(datatype))
+		if !s.options.Verbatim {
+			datatype = s.resolve(datatype)
+		}
+		return datatype
+	}
+
+	if s.options.Verbatim {
+		return datatype
+	}
+
+	for prefix, value := range s.prefixes {
+		if local, ok := strings.CutPrefix(datatype, prefix+":"); ok {
+			return value + unescapeLocal(local)
+		}
+	}
+
+	return datatype
+}
+
+// shorthandDatatype returns the datatype of the unquoted literal,
+// either a boolean or a number.
+func shorthandDatatype(token string) string {
+	switch {
+	case token == "true" || token == "false":
+		return rdf.XSDBoolean
+	case strings.ContainsAny(token, "eE"):
+		return rdf.XSDDouble
+	case strings.Contains(token, "."):
+		return rdf.XSDDecimal
+	}
+
+	return rdf.XSDInteger
+}
+
+// resolve resolves the IRI reference against the stored base.
+// Without a base the reference is kept as it is.
+func (s *Scanner) resolve(token string) string {
+	if s.base == "" {
+		return token
+	}
+
+	return resolveIRI(s.base, token)
+}
+
 var trimmedPairs = []struct {
 	left  string
 	right string
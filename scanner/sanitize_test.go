@@ -8,6 +8,7 @@ import (
 
 var sanitizeTestCases = map[string]struct {
 	base     string
+	prefixes map[string]string
 	input    string
 	token    string
 	label    string
@@ -20,6 +21,24 @@ var sanitizeTestCases = map[string]struct {
 		label: `en`,
 		typ:   "literal",
 	},
+	"with-normalized-label": {
+		input: `"this is an American text"@EN-us`,
+		token: `this is an American text`,
+		label: `en-US`,
+		typ:   "literal",
+	},
+	"with-direction": {
+		input: `"نص عربي"@ar--RTL`,
+		token: `نص عربي`,
+		label: `ar--rtl`,
+		typ:   "literal",
+	},
+	"with-invalid-label": {
+		input: `"this is not a language"@EN-abcdefghi`,
+		token: `this is not a language`,
+		label: `en-abcdefghi`,
+		typ:   "literal",
+	},
 	"with-datatype": {
 		input:    `"this is an English text"^^xsd:string`,
 		token:    `this is an English text`,
@@ -44,6 +63,62 @@ var sanitizeTestCases = map[string]struct {
 		label: `cs`,
 		typ:   "literal",
 	},
+	"escaped-literal": {
+		input: `"line\nbreak, \"quote\" and \\ backslash"@en`,
+		token: "line\nbreak, \"quote\" and \\ backslash",
+		label: `en`,
+		typ:   "literal",
+	},
+	"escaped-literal-unicode": {
+		input: `'\u010Cesk\u00E1 \U0001F577'`,
+		token: "Česká 🕷",
+		typ:   "literal",
+	},
+	"escaped-literal-invalid": {
+		input: `"\x and \uZZZZ"`,
+		token: `\x and \uZZZZ`,
+		typ:   "literal",
+	},
+	"escaped-quotes-literal-edge": {
+		input: `"\"quoted\""`,
+		token: `"quoted"`,
+		typ:   "literal",
+	},
+	"escaped-iri": {
+		input: `<http://example.org/\u010Cesko>`,
+		token: "http://example.org/Česko",
+		typ:   "iri",
+	},
+	"escaped-prefixed-name": {
+		prefixes: map[string]string{"ex": "http://example.org/"},
+		input:    `ex:a\.b\,c`,
+		token:    "http://example.org/a.b,c",
+		typ:      "iri",
+	},
+	"integer": {
+		input:    "-42",
+		token:    "-42",
+		datatype: "http://www.w3.org/2001/XMLSchema#integer",
+		typ:      "literal",
+	},
+	"decimal": {
+		input:    "+4.20",
+		token:    "+4.20",
+		datatype: "http://www.w3.org/2001/XMLSchema#decimal",
+		typ:      "literal",
+	},
+	"double": {
+		input:    ".5e-3",
+		token:    ".5e-3",
+		datatype: "http://www.w3.org/2001/XMLSchema#double",
+		typ:      "literal",
+	},
+	"boolean": {
+		input:    "false",
+		token:    "false",
+		datatype: "http://www.w3.org/2001/XMLSchema#boolean",
+		typ:      "literal",
+	},
 	"iri": {
 		base:  "http://example.org/",
 		input: "</path>",
@@ -56,7 +131,8 @@ func TestSanitize(t *testing.T) {
 	for name, tc := range sanitizeTestCases {
 		t.Run(name, func(t *testing.T) {
 			s := &Scanner{
-				base: tc.base,
+				base:     tc.base,
+				prefixes: tc.prefixes,
 			}
 			token, label, datatype, typ := s.sanitize(tc.input)
 			assert.Equal(t, tc.token, token, "function should have returned correctly sanitized token")
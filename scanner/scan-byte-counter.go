@@ -4,11 +4,33 @@ import "bufio"
 
 type scanByteCounter struct {
 	BytesRead int
+	// Token is the position of the last token returned by the split function.
+	Token Position
+	// pos is the position right after the data read so far.
+	pos Position
+	// comments is set when the comments are returned as tokens.
+	comments bool
+}
+
+func newScanByteCounter() *scanByteCounter {
+	return &scanByteCounter{
+		pos: Position{Line: 1, Column: 1},
+	}
 }
 
 func (s *scanByteCounter) splitFunc() bufio.SplitFunc {
 	return func(data []byte, atEOF bool) (int, []byte, error) {
-		adv, tok, err := splitTurtle(data, atEOF)
+		split := splitTurtle
+		if s.comments {
+			split = splitTurtleComments
+		}
+		adv, tok, err := split(data, atEOF)
+		if tok != nil {
+			// the token is a subslice of the data, its capacity
+			// tells how far from the beginning of the data it starts
+			s.Token = s.pos.advance(data[:cap(data)-cap(tok)])
+		}
+		s.pos = s.pos.advance(data[:adv])
 		s.BytesRead += adv
 		return adv, tok, err
 	}
@@ -1,14 +1,27 @@
 package scanner
 
 import (
+	"bytes"
 	"slices"
+	"strings"
 	"unicode"
 	"unicode/utf8"
 )
 
 func splitTurtle(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	return split(data, atEOF, false)
+}
+
+// splitTurtleComments returns the comments as tokens of their
+// own instead of skipping them along with the spaces.
+func splitTurtleComments(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	return split(data, atEOF, true)
+}
+
+func split(data []byte, atEOF bool, comments bool) (advance int, token []byte, err error) {
 	// skip leading spaces
 	start := 0
+	commentStart := 0
 	var comment bool
 	for width := 0; start < len(data); start += width {
 		var r rune
@@ -18,10 +31,14 @@ func splitTurtle(data []byte, atEOF bool) (advance int, token []byte, err error)
 		// is considered a leading space as well
 		if r == runeNumber && !comment { // #
 			comment = true
+			commentStart = start
 			continue
 		}
 
 		if r == runeNewLine && comment { // \n
+			if comments {
+				return start, data[commentStart:start], nil
+			}
 			comment = false
 			continue
 		}
@@ -31,38 +48,93 @@ func splitTurtle(data []byte, atEOF bool) (advance int, token []byte, err error)
 		}
 	}
 
+	// do not skip a comment that may continue in the data yet to be read
+	if comment && !atEOF {
+		return commentStart, nil, nil
+	}
+
+	// the comment at the end of the data
+	if comment && comments {
+		return start, data[commentStart:start], nil
+	}
+
 	// scan until space, marking end of word
-	var literal bool
-	var apostrophe bool
-	var quotationMark bool
 	var iri bool
 	var prefixedIri bool
-	var runeBuffer []rune
-	var inMultiLineLiteral bool
 	for width, i := 0, start; i < len(data); i += width {
 		var r rune
 		r, width = utf8.DecodeRune(data[i:])
 
-		// add rune to rune buffer
-		runeBuffer = appendRuneToBuffer(r, runeBuffer)
+		// if bumped into a quotation mark or an apostrophe and not in IRI,
+		// skip the whole literal up until its closing delimiter
+		if (r == runeQuotation || r == runeApostrophe) && !iri { // " '
+			if len(data) < i+len(longLiteralDelimiter(r)) && !atEOF {
+				return start, nil, nil
+			}
+
+			delimiter := literalDelimiter(data[i:], r)
+			end := literalEnd(data[i+len(delimiter):], delimiter)
+			if end == -1 {
+				if !atEOF {
+					return start, nil, nil
+				}
+				// an unterminated literal, let the scanner report it
+				return len(data), data[start:], nil
+			}
+
+			i, width = i+len(delimiter)+end, 0
+			continue
+		}
+
+		// the delimiters of quoted triples and annotations are words
+		// on their own even when not separated by spaces
+		if !iri && slices.Contains(starCharacters, r) {
+			if len(data) < i+2 && !atEOF {
+				return start, nil, nil
+			}
 
-		multilineLiteralEdge := bufferContainsLiterals(runeBuffer)
-		escaped := escapedCharacter(runeBuffer)
-		// if the last characters were literals, switch the multiline
-		// literal and literal state
-		if multilineLiteralEdge {
-			inMultiLineLiteral = !inMultiLineLiteral
-			literal = !literal
+			if delimiter := starDelimiter(data[i:]); delimiter != "" {
+				if i == start {
+					return i + len(delimiter), data[i : i+len(delimiter)], nil
+				}
+				return i, data[start:i], nil
+			}
 		}
 
-		// if we bump to space character, we return the word, unless there is a literal started
-		if unicode.IsSpace(r) && !literal {
+		// a backslash in a prefixed name escapes the following character,
+		// e.g. a full stop, that is then part of the name
+		if r == runeBackslash && prefixedIri && !iri {
+			if !utf8.FullRune(data[i+width:]) && !atEOF {
+				return start, nil, nil
+			}
+			_, escapedWidth := utf8.DecodeRune(data[i+width:])
+			width += escapedWidth
+			continue
+		}
+
+		// if we bump to space character, we return the word
+		if unicode.IsSpace(r) {
 			return i + width, data[start:i], nil
 		}
 
-		// if prefixed iri and one of the key characters and not literal and number does not follow
-		// set the prefixed uri state to false
-		if slices.Contains(keyCharacters, r) && !iri && !literal && prefixedIri {
+		// a full stop is part of a prefixed name or a blank node label unless
+		// it ends the name, i.e. only full stops are followed by a space,
+		// the end of the data or a delimiter
+		if r == runeFullStop && !iri && prefixedIri {
+			after, ok := afterFullStops(data[i:])
+			if !ok && !atEOF {
+				return start, nil, nil
+			}
+
+			if !ok || !continuesName(after) {
+				prefixedIri = false
+			}
+		} else if slices.Contains(keyCharacters, r) && !iri && prefixedIri {
+			// if prefixed iri and one of the key characters and not literal and number does not follow
+			// set the prefixed uri state to false
+			if !utf8.FullRune(data[i+width:]) && !atEOF {
+				return start, nil, nil
+			}
 			after, _ := utf8.DecodeRune(data[i+width:])
 
 			if !unicode.IsDigit(after) {
@@ -72,13 +144,22 @@ func splitTurtle(data []byte, atEOF bool) (advance int, token []byte, err error)
 
 		// if dot of a float (after it number) and not in iri and not in literal
 		// return the float number
-		if r == runeFullStop && !iri && !literal && !prefixedIri {
+		if r == runeFullStop && !iri && !prefixedIri {
+			if !utf8.FullRune(data[i+width:]) && !atEOF {
+				return start, nil, nil
+			}
 			after, afterWidth := utf8.DecodeRune(data[i+width:])
 
 			if unicode.IsDigit(after) {
 				width = width + afterWidth
 				var datatype bool
 				for {
+					if !utf8.FullRune(data[i+width:]) {
+						if !atEOF {
+							return start, nil, nil
+						}
+						break
+					}
 					nextRune, runeWidth := utf8.DecodeRune(data[i+width:])
 
 					if nextRune == runeCaret {
@@ -99,7 +180,7 @@ func splitTurtle(data []byte, atEOF bool) (advance int, token []byte, err error)
 			}
 		}
 
-		if slices.Contains(keyCharacters, r) && !iri && !literal && !prefixedIri { // ; , . [
+		if slices.Contains(keyCharacters, r) && !iri && !prefixedIri { // ; , . [
 			// if it is first character, we return it as the word
 			if i == 0 || start == i {
 				return i + width, data[start : i+width], nil
@@ -108,26 +189,12 @@ func splitTurtle(data []byte, atEOF bool) (advance int, token []byte, err error)
 			return i, data[start:i], nil
 		}
 
-		// if bumbed into quotation mark and not in apostrophe literal,
-		// switch the literal and quotation mark state
-		if r == runeQuotation && !apostrophe && !inMultiLineLiteral && !multilineLiteralEdge && !escaped { // "
-			literal = !literal
-			quotationMark = !quotationMark
-		}
-
-		// if bumbed into apostrophe and not in quotation mark literal,
-		// switch the literal state and quotation mark state
-		if r == runeApostrophe && !quotationMark && !inMultiLineLiteral && !multilineLiteralEdge && !escaped { // '
-			literal = !literal
-			apostrophe = !apostrophe
-		}
-
-		if len(runeBuffer) == 1 && !literal && !unicode.IsDigit(r) {
+		if i == start && !unicode.IsDigit(r) {
 			prefixedIri = true
 		}
 
-		// if bumbed into the border of IRI and not in literal, switch the IRI state
-		if (r == runeLessThan || r == runeGreaterThan) && !literal { // < >
+		// if bumbed into the border of IRI, switch the IRI state
+		if r == runeLessThan || r == runeGreaterThan { // < >
 			iri = !iri
 		}
 
@@ -141,3 +208,67 @@ func splitTurtle(data []byte, atEOF bool) (advance int, token []byte, err error)
 	// request more data.
 	return start, nil, nil
 }
+
+// afterFullStops returns the character following the full stops the data
+// starts with. It fails if the data ends before the character is complete.
+func afterFullStops(data []byte) (rune, bool) {
+	data = bytes.TrimLeft(data, ".")
+	if !utf8.FullRune(data) {
+		return 0, false
+	}
+
+	r, _ := utf8.DecodeRune(data)
+	return r, true
+}
+
+// continuesName reports whether the character following a full stop
+// makes the full stop part of a name instead of its end.
+func continuesName(r rune) bool {
+	return !unicode.IsSpace(r) && !slices.Contains(keyCharacters, r) && !slices.Contains(nameDelimiters, r)
+}
+
+// starDelimiter returns the delimiter of a quoted triple or an annotation
+// the data starts with, an empty string if there is none.
+func starDelimiter(data []byte) string {
+	for _, delimiter := range starDelimiters {
+		if bytes.HasPrefix(data, []byte(delimiter)) {
+			return delimiter
+		}
+	}
+
+	return ""
+}
+
+// longLiteralDelimiter returns the delimiter of a long literal
+// starting with the given quotation mark or apostrophe.
+func longLiteralDelimiter(r rune) string {
+	return strings.Repeat(string(r), 3)
+}
+
+// literalDelimiter returns the delimiter the literal at the beginning
+// of the data starts with, either a single quote or three of them.
+func literalDelimiter(data []byte, r rune) string {
+	if long := longLiteralDelimiter(r); bytes.HasPrefix(data, []byte(long)) {
+		return long
+	}
+	return string(r)
+}
+
+// literalEnd returns the index right after the closing delimiter
+// of a literal whose content starts at the beginning of the data
+// or -1 if the literal is not closed. Escaped characters do not
+// close the literal.
+func literalEnd(data []byte, delimiter string) int {
+	for i := 0; i < len(data); i++ {
+		if data[i] == runeBackslash {
+			i++
+			continue
+		}
+
+		if bytes.HasPrefix(data[i:], []byte(delimiter)) {
+			return i + len(delimiter)
+		}
+	}
+
+	return -1
+}
@@ -4,7 +4,10 @@ import (
 	"bufio"
 	"bytes"
 	"fmt"
+	"io"
+	"maps"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -16,17 +19,38 @@ type Options struct {
 	// base of the prefix on match, and the prefix applied. Resource tags ("<>")
 	// will be omitted from this representation.
 	Prefixes map[string]string
+	// If set, the data has to follow the Turtle grammar exactly. Every
+	// statement has to be terminated, every term has to be valid and
+	// every prefix has to be declared, otherwise the scanner stops
+	// with a syntax error.
+	Strict bool
+	// If set, the IRIs are returned as they were written, neither
+	// resolved against the base nor expanded by the prefixes.
+	Verbatim bool
+	// If set, the data is read as TriG, i.e. Turtle whose triples can be
+	// enclosed in the blocks of the default or named graphs. Otherwise the
+	// graph blocks are a syntax error.
+	TriG bool
+	// If set, the handler is called for the directives, triples,
+	// comments, statement ends, blank node property lists, collections
+	// and errors as the data is read.
+	Handler Handler
 }
 
+// maxTokenSize limits the size of a single token, e.g. a long literal,
+// the scanner is able to hold in its buffer.
+const maxTokenSize = 64 * 1024 * 1024
+
 var regexBlankNode = regexp.MustCompile(`_:.+`)
 
-// Scanner uses bufio.Scanner to parse the provided byte slice word by word.
+// Scanner uses bufio.Scanner to parse the provided data word by word.
 // It keeps information about prefixes and base of the provided graph and
 // the next triple to be read.
 type Scanner struct {
 	options          Options
+	handler          Handler
 	t                [][6]string
-	data             []byte
+	pending          []string
 	scanByteCounter  *scanByteCounter
 	s                *bufio.Scanner
 	base             string
@@ -38,6 +62,29 @@ type Scanner struct {
 	curIndex         int
 	bnLists          []blankNodeList
 	colls            []collection
+	pos              Position
+	err              error
+	// inStatement is set when a subject of the current statement was read
+	inStatement bool
+	// incomplete is set when a subject or a predicate was read without an object
+	incomplete bool
+	// listSubject is set when a non-empty blank node list was closed in place of a subject
+	listSubject bool
+	objectCount int
+	// graph is the name of the TriG graph block the scanner is in,
+	// empty for the default graph
+	graph string
+	// inGraph is set when a graph block was opened and not closed yet
+	inGraph bool
+	// graphKeyword is set when the GRAPH keyword was read without
+	// the graph block it introduces
+	graphKeyword bool
+	// quoted holds the quoted triples being read, the innermost last
+	quoted []quotedTriple
+	// annotations holds the annotations being read, the innermost last
+	annotations []annotation
+	// lastObject is the object of the last triple, the one an annotation refers to
+	lastObject collectionItem
 }
 
 type blankNodeList struct {
@@ -46,6 +93,9 @@ type blankNodeList struct {
 	curSubject   string
 	curPredicate string
 	blankNode    string
+	inStatement  bool
+	incomplete   bool
+	objectCount  int
 }
 
 type collection struct {
@@ -56,6 +106,27 @@ type collection struct {
 	items        []collectionItem
 }
 
+// quotedTriple is a triple of RDF-star enclosed in << and >>
+// that takes the place of a single term.
+type quotedTriple struct {
+	start        int
+	curIndex     int
+	curSubject   string
+	curPredicate string
+	inStatement  bool
+	incomplete   bool
+	// terms are the terms read so far in the N-Triples form
+	terms []string
+}
+
+// annotation is a list of the predicates and objects enclosed in {| and |}
+// following a triple, which is their quoted subject.
+type annotation struct {
+	start        int
+	curSubject   string
+	curPredicate string
+}
+
 type collectionItem struct {
 	token     string
 	label     string
@@ -69,21 +140,43 @@ func New(data []byte) *Scanner {
 	return NewWithOptions(data, Options{})
 }
 
+// NewWithOptions accepts a byte slice of the Turtle data and returns
+// a new scanner.Scanner with options to tweak its behavior. See Options.
 func NewWithOptions(data []byte, options Options) *Scanner {
-	counter := &scanByteCounter{}
-	s := newBufioScanner(data)
+	return NewReaderWithOptions(bytes.NewReader(data), options)
+}
+
+// NewReader accepts an io.Reader of the Turtle data and returns
+// a new scanner.Scanner. The data is read from the reader only
+// as far as it is needed to extract the next triple.
+func NewReader(r io.Reader) *Scanner {
+	return NewReaderWithOptions(r, Options{})
+}
+
+// NewReaderWithOptions accepts an io.Reader of the Turtle data and
+// returns a new scanner.Scanner with options to tweak its behavior.
+// See Options.
+func NewReaderWithOptions(r io.Reader, options Options) *Scanner {
+	counter := newScanByteCounter()
+	counter.comments = options.Handler != nil
+	s := newBufioScanner(r)
 	s.Split(counter.splitFunc())
 
 	base := options.Base
-	prefixes := options.Prefixes
+	prefixes := maps.Clone(options.Prefixes)
 
 	if prefixes == nil {
 		prefixes = make(map[string]string)
 	}
 
+	var handler Handler = NopHandler{}
+	if options.Handler != nil {
+		handler = options.Handler
+	}
+
 	return &Scanner{
 		options:         options,
-		data:            data,
+		handler:         handler,
 		scanByteCounter: counter,
 		s:               s,
 		t:               make([][6]string, 0),
@@ -109,70 +202,296 @@ func (s *Scanner) Next() bool {
 		return true
 	}
 
+	// stop at the first error
+	if s.err != nil {
+		return false
+	}
+
 	// otherwise look for next triples
 	for {
-		//beforeI := s.scanByteCounter.BytesRead
-		if ok := s.s.Scan(); !ok {
-			return false
+		token, ok := s.scan()
+		if !ok {
+			return s.end()
 		}
 
 		i := s.scanByteCounter.BytesRead
 
-		token := s.s.Text()
-
 		// if bumped into a prefix form, extract and store the prefix and its value
 		if token == "@prefix" || strings.ToLower(token) == "prefix" {
-			if ok := s.s.Scan(); !ok {
-				return false
+			if s.options.Strict && s.inDirective() {
+				return s.fail(token, s.expected())
+			}
+			pos := s.pos
+
+			prefix, ok := s.scan()
+			if !ok {
+				return s.end() || s.fail("", "prefix name")
 			}
 
-			prefix := s.s.Text()
+			if !strings.HasSuffix(prefix, ":") {
+				return s.fail(prefix, "prefix name ending with a colon")
+			}
 
-			if len(prefix) == 0 {
-				continue
+			if s.options.Strict && !regexPrefixName.MatchString(prefix) {
+				return s.fail(prefix, "prefix name")
 			}
 
 			prefix = prefix[:len(prefix)-1]
 
-			if ok := s.s.Scan(); !ok {
-				return false
+			value, ok := s.scan()
+			if !ok {
+				return s.end() || s.fail("", "IRI")
 			}
 
-			value := strings.Trim(s.s.Text(), "<>")
+			if !isIRIRef(value) {
+				return s.fail(value, "IRI")
+			}
+
+			value = unescapeIRI(strings.Trim(value, "<>"))
+			if !s.options.Verbatim {
+				value = s.resolve(value)
+			}
 
 			s.prefixes[prefix] = value
+			s.handler.OnPrefix(pos, prefix, value)
+
+			if token == "@prefix" && !s.directiveEnd() {
+				return false
+			}
 			continue
 		}
 
 		// if bumped into a base form, extract and store its value
 		if token == "@base" || strings.ToLower(token) == "base" {
-			if ok := s.s.Scan(); !ok {
+			if s.options.Strict && s.inDirective() {
+				return s.fail(token, s.expected())
+			}
+			pos := s.pos
+
+			value, ok := s.scan()
+			if !ok {
+				return s.end() || s.fail("", "IRI")
+			}
+
+			if !isIRIRef(value) {
+				return s.fail(value, "IRI")
+			}
+
+			// the base can be relative to the previous one
+			value = unescapeIRI(strings.Trim(value, "<>"))
+			if !s.options.Verbatim {
+				value = s.resolve(value)
+			}
+			s.base = value
+			s.handler.OnBase(pos, value)
+
+			if token == "@base" && !s.directiveEnd() {
 				return false
 			}
+			continue
+		}
 
-			s.base = strings.Trim(s.s.Text(), "<>")
+		// the GRAPH keyword introduces the name of a graph block
+		if s.options.TriG && strings.EqualFold(token, "graph") && !s.inStatement && !s.inGraph && !s.graphKeyword && len(s.pending) == 0 {
+			s.graphKeyword = true
+			continue
+		}
 
+		// beginning of a graph block, either of the default graph
+		// or of the graph named by the preceding term
+		if token == "{" {
+			if !s.options.TriG || s.inGraph || len(s.bnLists) > 0 || len(s.colls) > 0 || len(s.quoted) > 0 || len(s.annotations) > 0 {
+				return s.fail(token, s.expected())
+			}
+
+			switch {
+			case s.inStatement && s.curIndex == 1 && s.incomplete:
+				s.graph = s.curSubject
+			case s.graphKeyword:
+				return s.fail(token, "graph name")
+			case !s.inStatement:
+				s.graph = ""
+			default:
+				return s.fail(token, s.expected())
+			}
+
+			s.inGraph = true
+			s.graphKeyword = false
+			s.curIndex = 0
+			s.inStatement = false
+			s.incomplete = false
+			continue
+		}
+
+		// ending of a graph block, its last statement does not have to be terminated
+		if token == "}" {
+			if !s.inGraph || s.incomplete || len(s.bnLists) > 0 || len(s.colls) > 0 || len(s.quoted) > 0 || len(s.annotations) > 0 {
+				return s.fail(token, s.expected())
+			}
+			if s.inStatement {
+				s.handler.OnStatementEnd(s.pos)
+			}
+
+			s.inGraph = false
+			s.graph = ""
+			s.curIndex = 0
+			s.inStatement = false
+			continue
+		}
+
+		// ending of a quoted triple, which takes the place of a single term
+		if token == ">>" {
+			if len(s.quoted) == 0 || s.curIndex != 3 {
+				return s.fail(token, s.expected())
+			}
+			q := s.quoted[len(s.quoted)-1]
+			s.quoted = s.quoted[:len(s.quoted)-1]
+
+			s.pending = append(s.pending, quoteTriple(q.terms))
+			s.curSubject = q.curSubject
+			s.curPredicate = q.curPredicate
+			s.curIndex = q.curIndex
+			s.inStatement = q.inStatement
+			s.incomplete = q.incomplete
+			continue
+		}
+
+		// beginning of an annotation, the last triple quoted
+		// is the subject of the annotation's triples
+		if token == "{|" {
+			if !s.inStatement || s.incomplete || s.curIndex != 0 || s.inCollection() || len(s.quoted) > 0 {
+				return s.fail(token, s.expected())
+			}
+			s.annotations = append(s.annotations, annotation{
+				start:        i,
+				curSubject:   s.curSubject,
+				curPredicate: s.curPredicate,
+			})
+			s.curSubject = quoteTriple([]string{
+				quote(s.curSubject, "", "", "iri"),
+				quote(s.curPredicate, "", "", "iri"),
+				quote(s.lastObject.token, s.lastObject.label, s.lastObject.datatype, s.lastObject.typ),
+			})
+			s.curIndex = 1
+			s.incomplete = true
+			continue
+		}
+
+		// ending of an annotation
+		if token == "|}" {
+			if !s.inAnnotation() || s.incomplete {
+				return s.fail(token, s.expected())
+			}
+			a := s.annotations[len(s.annotations)-1]
+			s.annotations = s.annotations[:len(s.annotations)-1]
+
+			s.curSubject = a.curSubject
+			s.curPredicate = a.curPredicate
+			s.curIndex = 0
 			continue
 		}
 
 		// multiple predicates of a single subject
 		if token == ";" {
+			if !s.inStatement || s.incomplete || s.inCollection() || len(s.quoted) > 0 {
+				return s.fail(token, s.expected())
+			}
 			s.curIndex = 1
 			continue
 		}
 
 		// multiple objects of a single predicate
 		if token == "," {
+			if !s.inStatement || s.incomplete || s.inCollection() || len(s.quoted) > 0 {
+				return s.fail(token, s.expected())
+			}
+			// in strict mode a comma has to follow an object
+			if s.options.Strict && s.curIndex != 0 {
+				return s.fail(token, s.expected())
+			}
 			s.curIndex = 2
 			continue
 		}
 
 		// ignore the "end of triple" keyword
 		if token == "." {
+			if len(s.quoted) > 0 {
+				return s.fail(token, s.expected())
+			}
+			if len(s.annotations) > 0 {
+				return s.fail(token, `"|}"`)
+			}
+			if len(s.bnLists) > 0 {
+				return s.fail(token, `"]"`)
+			}
+			if len(s.colls) > 0 {
+				return s.fail(token, `")"`)
+			}
+			if s.incomplete || (s.options.Strict && !s.inStatement) {
+				return s.fail(token, s.expected())
+			}
+			if s.inStatement {
+				s.handler.OnStatementEnd(s.pos)
+			}
 			s.curIndex = 0
+			s.inStatement = false
 			continue
 		}
 
+		// in strict mode a statement has to be terminated before another one
+		if s.options.Strict && token != "]" && s.missingTerminator() {
+			return s.fail(token, s.expected())
+		}
+
+		// blank node lists and collections cannot stand in place of a predicate
+		if s.options.Strict && (token == "[" || token == "(") && s.curIndex == 1 && !s.inCollection() {
+			return s.fail(token, "predicate")
+		}
+
+		// beginning of a quoted triple
+		if token == "<<" {
+			if s.curIndex == 1 && !s.inCollection() {
+				return s.fail(token, "predicate")
+			}
+			if s.curIndex == 3 {
+				return s.fail(token, s.expected())
+			}
+			s.quoted = append(s.quoted, quotedTriple{
+				start:        i,
+				curIndex:     s.curIndex,
+				curSubject:   s.curSubject,
+				curPredicate: s.curPredicate,
+				inStatement:  s.inStatement,
+				incomplete:   s.incomplete,
+			})
+			s.curIndex = 0
+			s.inStatement = true
+			s.incomplete = true
+			continue
+		}
+
+		// a quoted triple can hold only an empty blank node list
+		if token == "[" && len(s.quoted) > 0 {
+			start := s.pos
+			next, ok := s.scan()
+			if !ok {
+				return s.end() || s.fail("", `"]"`)
+			}
+			if next != "]" {
+				return s.fail(next, `"]"`)
+			}
+			blankNode := s.newBlankNode()
+			s.handler.OnBlankNodeListStart(start, blankNode)
+			s.handler.OnBlankNodeListEnd(s.pos, blankNode)
+			s.pending = append(s.pending, blankNode)
+			continue
+		}
+
+		// collections cannot be part of a quoted triple
+		if token == "(" && len(s.quoted) > 0 {
+			return s.fail(token, s.expected())
+		}
+
 		// beginning of a blank node list
 		if token == "[" {
 			blankNode := s.newBlankNode()
@@ -182,30 +501,38 @@ func (s *Scanner) Next() bool {
 				curPredicate: s.curPredicate,
 				curIndex:     s.curIndex,
 				blankNode:    blankNode,
+				inStatement:  s.inStatement,
+				incomplete:   s.incomplete,
+				objectCount:  s.objectCount,
 			})
 			s.curSubject = blankNode
 			s.curIndex = 1
+			s.inStatement = true
+			s.incomplete = false
+			s.handler.OnBlankNodeListStart(s.pos, blankNode)
 			continue
 		}
 
 		// ending of a blank node list
 		if token == "]" {
-			if len(s.bnLists) == 0 {
-				continue
+			if len(s.bnLists) == 0 || s.inAnnotation() {
+				return s.fail(token, s.expected())
+			}
+			if s.incomplete {
+				return s.fail(token, s.expected())
 			}
 			list := s.bnLists[len(s.bnLists)-1]
 			s.bnLists = s.bnLists[:len(s.bnLists)-1]
+			s.handler.OnBlankNodeListEnd(s.pos, list.blankNode)
 
-			newData := make([]byte, 0)
-			newData = append(newData, []byte(list.blankNode)...)
-			newData = append(newData, s.data[i:]...)
-			s.data = newData
-			s.s = newBufioScanner(s.data)
-			s.scanByteCounter = &scanByteCounter{}
-			s.s.Split(s.scanByteCounter.splitFunc())
+			// the blank node takes the place of the whole list
+			s.pending = append(s.pending, list.blankNode)
 			s.curSubject = list.curSubject
 			s.curPredicate = list.curPredicate
 			s.curIndex = list.curIndex
+			s.inStatement = list.inStatement
+			s.incomplete = list.incomplete
+			s.listSubject = list.curIndex == 0 && list.objectCount < s.objectCount
 			continue
 		}
 
@@ -220,11 +547,22 @@ func (s *Scanner) Next() bool {
 			}
 
 			s.colls = append(s.colls, col)
+			s.handler.OnCollectionStart(s.pos)
 
 			continue
 		}
 
+		if missing := unterminated(token); missing != "" {
+			return s.fail(token, strconv.Quote(missing))
+		}
+
 		if token != ")" && s.inCollection() {
+			if s.options.Strict {
+				if expected := s.validate(token, positionObject); expected != "" {
+					return s.fail(token, expected)
+				}
+			}
+
 			token, label, datatype, typ := s.sanitize(token)
 			item := collectionItem{
 				token:     token,
@@ -240,7 +578,7 @@ func (s *Scanner) Next() bool {
 
 		if token == ")" {
 			if len(s.colls) == 0 {
-				continue
+				return s.fail(token, s.expected())
 			}
 
 			lastCollection := s.colls[len(s.colls)-1]
@@ -249,27 +587,24 @@ func (s *Scanner) Next() bool {
 
 			for i, item := range lastCollection.items {
 				// rdf first
-				s.t = append(s.t, [6]string{item.blankNode, rdfFirst, item.token, item.label, item.datatype, item.typ})
+				s.emit([6]string{item.blankNode, rdfFirst, item.token, item.label, item.datatype, item.typ})
 				// rdf rest
 				rest := rdfNil
 				if i < len(lastCollection.items)-1 {
 					rest = lastCollection.items[i+1].blankNode
 				}
-				s.t = append(s.t, [6]string{item.blankNode, rdfRest, rest, "", "", "iri"})
+				s.emit([6]string{item.blankNode, rdfRest, rest, "", "", "iri"})
 			}
 
-			collectionStart := rdfNilInTurtle
+			collectionStart, head := rdfNilInTurtle, rdfNil
 			if len(lastCollection.items) > 0 {
 				collectionStart = lastCollection.items[0].blankNode
+				head = collectionStart
 			}
 
-			newData := make([]byte, 0)
-			newData = append(newData, []byte(collectionStart)...)
-			newData = append(newData, s.data[i:]...)
-			s.data = newData
-			s.s = newBufioScanner(s.data)
-			s.scanByteCounter = &scanByteCounter{}
-			s.s.Split(s.scanByteCounter.splitFunc())
+			// the first node takes the place of the whole collection
+			s.pending = append(s.pending, collectionStart)
+			s.handler.OnCollectionEnd(s.pos, head)
 
 			s.curIndex = lastCollection.curIndex
 			s.curSubject = lastCollection.curSubject
@@ -282,17 +617,57 @@ func (s *Scanner) Next() bool {
 			continue
 		}
 
+		// a quoted triple has three terms only
+		if len(s.quoted) > 0 && s.curIndex == 3 {
+			return s.fail(token, `">>"`)
+		}
+
+		if s.options.Strict {
+			if expected := s.validate(token, s.curIndex); expected != "" {
+				return s.fail(token, expected)
+			}
+		}
+
 		token, label, datatype, typ := s.sanitize(token)
 
 		// record blank node
-		if regexBlankNode.MatchString(token) {
+		if typ != typeTriple && regexBlankNode.MatchString(token) {
 			s.blankNodes[token] = struct{}{}
 		}
 
+		// the terms of a quoted triple make up a single term
+		if len(s.quoted) > 0 {
+			if s.curIndex != 2 {
+				typ = "iri"
+			}
+			q := &s.quoted[len(s.quoted)-1]
+			q.terms = append(q.terms, quote(token, label, datatype, typ))
+			s.curIndex++
+			s.incomplete = s.curIndex < 3
+			continue
+		}
+
 		// handle subject
 		if s.curIndex == 0 {
 			s.curSubject = token
 			s.curIndex++
+			s.inStatement = true
+			// a blank node list as a subject may stand alone
+			s.incomplete = !s.listSubject
+			s.listSubject = false
+
+			// the name of a graph block has to be followed by the block
+			if s.graphKeyword {
+				next, ok := s.scan()
+				if !ok {
+					s.end()
+					return s.fail("", `"{"`)
+				}
+				if next != "{" {
+					return s.fail(next, `"{"`)
+				}
+				s.pending = append(s.pending, next)
+			}
 			continue
 		}
 
@@ -300,18 +675,151 @@ func (s *Scanner) Next() bool {
 		if s.curIndex == 1 {
 			s.curPredicate = token
 			s.curIndex++
+			s.incomplete = true
 			continue
 		}
 
 		// handle object
 		if s.curIndex == 2 {
-			s.t = append(s.t, [6]string{s.curSubject, s.curPredicate, token, label, datatype, typ})
+			s.emit([6]string{s.curSubject, s.curPredicate, token, label, datatype, typ})
+			s.lastObject = collectionItem{token: token, label: label, datatype: datatype, typ: typ}
 			s.curIndex = 0
+			s.incomplete = false
+			s.objectCount++
 			return true
 		}
 	}
 }
 
+// Err returns the first error encountered by the Scanner. It is either
+// a *SyntaxError describing the malformed data or an error returned
+// while reading the data.
+func (s *Scanner) Err() error {
+	if err := s.s.Err(); err != nil {
+		return err
+	}
+
+	return s.err
+}
+
+// end checks that the data did not end in the middle of a statement.
+// It always returns false so that it can be returned by Next.
+func (s *Scanner) end() bool {
+	if err := s.s.Err(); err != nil {
+		if s.err == nil {
+			s.err = err
+			s.handler.OnError(err)
+		}
+		return false
+	}
+
+	s.pos = s.scanByteCounter.pos
+
+	switch {
+	case len(s.bnLists) > 0:
+		return s.fail("", `"]"`)
+	case len(s.colls) > 0:
+		return s.fail("", `")"`)
+	case len(s.quoted) > 0:
+		return s.fail("", s.expected())
+	case len(s.annotations) > 0:
+		return s.fail("", `"|}"`)
+	case s.incomplete:
+		return s.fail("", s.expected())
+	case s.graphKeyword:
+		return s.fail("", "graph name")
+	case s.inGraph:
+		return s.fail("", `"}"`)
+	case s.options.Strict && s.inStatement:
+		return s.fail("", `"."`)
+	}
+
+	return false
+}
+
+// inDirective reports whether a directive would be placed
+// inside of a statement.
+func (s *Scanner) inDirective() bool {
+	return s.inStatement || s.inGraph || s.graphKeyword || len(s.bnLists) > 0 || len(s.colls) > 0
+}
+
+// missingTerminator reports whether the next term would start a new
+// statement without the previous one being terminated.
+func (s *Scanner) missingTerminator() bool {
+	if s.inCollection() {
+		return false
+	}
+
+	return s.inStatement && s.curIndex == 0 && !s.incomplete
+}
+
+// directiveEnd reads the full stop terminating the @prefix and @base
+// directives. The scanner does so only in strict mode, otherwise the
+// full stop is skipped along with the other tokens.
+func (s *Scanner) directiveEnd() bool {
+	if !s.options.Strict {
+		return true
+	}
+
+	token, ok := s.scan()
+	if !ok {
+		return s.end() || s.fail("", `"."`)
+	}
+
+	if token != "." {
+		return s.fail(token, `"."`)
+	}
+
+	return true
+}
+
+// fail records a syntax error at the position of the current token.
+// It always returns false so that it can be returned by Next.
+func (s *Scanner) fail(token, expected string) bool {
+	if s.err == nil {
+		s.err = &SyntaxError{
+			Position: s.pos,
+			Token:    token,
+			Expected: expected,
+		}
+		s.handler.OnError(s.err)
+	}
+
+	return false
+}
+
+// expected describes what kind of token the scanner expects next.
+func (s *Scanner) expected() string {
+	switch {
+	case len(s.quoted) > 0:
+		return [...]string{"subject", "predicate", "object", `">>"`}[s.curIndex]
+	case s.curIndex == 1 && s.incomplete:
+		return "predicate"
+	case s.inCollection():
+		return `object or ")"`
+	case s.curIndex == 2:
+		return "object"
+	case s.inStatement && s.inAnnotation():
+		return `";", "," or "|}"`
+	case s.inStatement && len(s.bnLists) > 0:
+		return `";", "," or "]"`
+	case s.inStatement && s.inGraph:
+		return `";", ",", "." or "}"`
+	case s.inStatement:
+		return `";", "," or "."`
+	case s.inGraph:
+		return `subject or "}"`
+	default:
+		return "subject"
+	}
+}
+
+// emit stores the triple to be returned by Next and passes it to the handler.
+func (s *Scanner) emit(t [6]string) {
+	s.t = append(s.t, t)
+	s.handler.OnTriple(s.pos, t, s.graph)
+}
+
 // Triple returns the next triple
 func (s *Scanner) Triple() [3]string {
 	if len(s.t) == 0 {
@@ -328,6 +836,15 @@ func (s *Scanner) TripleWithAnnotations() [6]string {
 	return s.t[0]
 }
 
+// Graph returns the name of the graph of the next triple,
+// an empty string for the default graph.
+func (s *Scanner) Graph() string {
+	if len(s.t) == 0 {
+		return ""
+	}
+	return s.graph
+}
+
 // newBlankNode emits a new blank node based on what is the
 // blank node ID counter and what blank node have already
 // been recorded in the dataset to avoid collisions
@@ -349,15 +866,87 @@ func (s *Scanner) inCollection() bool {
 		return false
 	}
 
+	start := s.colls[len(s.colls)-1].start
+	if len(s.quoted) > 0 && s.quoted[len(s.quoted)-1].start > start {
+		return false
+	}
+
 	if len(s.bnLists) == 0 {
 		return true
 	}
 
-	return s.colls[len(s.colls)-1].start > s.bnLists[len(s.bnLists)-1].start
+	return start > s.bnLists[len(s.bnLists)-1].start
+}
+
+// inAnnotation reports whether an annotation was opened
+// after the blank node lists and collections being read.
+func (s *Scanner) inAnnotation() bool {
+	if len(s.annotations) == 0 || len(s.quoted) > 0 {
+		return false
+	}
+
+	start := s.annotations[len(s.annotations)-1].start
+	if len(s.bnLists) > 0 && s.bnLists[len(s.bnLists)-1].start > start {
+		return false
+	}
+
+	return len(s.colls) == 0 || s.colls[len(s.colls)-1].start < start
+}
+
+// scan returns the next token, either the one pushed back in place
+// of a closed blank node list or collection or the next one read
+// by the underlying bufio.Scanner. The comments are passed to the
+// handler instead.
+func (s *Scanner) scan() (string, bool) {
+	if len(s.pending) > 0 {
+		token := s.pending[len(s.pending)-1]
+		s.pending = s.pending[:len(s.pending)-1]
+		return token, true
+	}
+
+	for {
+		if ok := s.s.Scan(); !ok {
+			return "", false
+		}
+
+		s.pos = s.scanByteCounter.Token
+		comment, ok := strings.CutPrefix(s.s.Text(), "#")
+		if !ok {
+			return s.s.Text(), true
+		}
+		s.handler.OnComment(s.pos, strings.TrimSuffix(comment, "\r"))
+	}
+}
+
+// unterminated returns the delimiter missing at the end of an IRI
+// or a literal that was not closed before the end of the data.
+func unterminated(token string) string {
+	if strings.HasPrefix(token, "<") && !strings.Contains(token, ">") {
+		return ">"
+	}
+
+	for _, delimiter := range literalDelimiters {
+		if !strings.HasPrefix(token, delimiter) {
+			continue
+		}
+
+		if literalEnd([]byte(token[len(delimiter):]), delimiter) == -1 {
+			return delimiter
+		}
+
+		return ""
+	}
+
+	return ""
+}
+
+// isIRIRef reports whether the token is an IRI enclosed in angle brackets.
+func isIRIRef(token string) bool {
+	return len(token) >= 2 && strings.HasPrefix(token, "<") && strings.HasSuffix(token, ">")
 }
 
-func newBufioScanner(data []byte) *bufio.Scanner {
-	s := bufio.NewScanner(bytes.NewReader(data))
-	s.Buffer(data, 0)
+func newBufioScanner(r io.Reader) *bufio.Scanner {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxTokenSize)
 	return s
 }
@@ -4,8 +4,10 @@ import (
 	"bufio"
 	"bytes"
 	"testing"
+	"testing/iotest"
 
 	"github.com/nvkp/turtle/assert"
+	"github.com/nvkp/turtle/rdf"
 )
 
 var scanTestCases = map[string]struct {
@@ -175,7 +177,7 @@ var scanTestCases = map[string]struct {
 			".",
 		},
 		expectedTriples: [][3]string{
-			{"http://somecountry.example/census2007", "http://example.org/stats/isLandlocked", "false"},
+			{"http://somecountry.example/census2007", "http://example.org/statsisLandlocked", "false"},
 		},
 	},
 	"base_no_ending_slash": {
@@ -592,7 +594,7 @@ var scanTestCases = map[string]struct {
 		expectedTriples: [][3]string{
 			{"https://schema.org/FAQPage", "http://www.w3.org/1999/02/22-rdf-syntax-ns#type", "http://www.w3.org/2000/01/rdf-schema#Class"},
 			{"https://schema.org/FAQPage", "http://www.w3.org/2000/01/rdf-schema#label", "FAQPage"},
-			{"https://schema.org/FAQPage", "http://www.w3.org/2000/01/rdf-schema#comment", `A [[FAQPage]] is a [[WebPage]] presenting one or more \"[Frequently asked questions](https://en.wikipedia.org/wiki/FAQ)\" (see also [[QAPage]]).`},
+			{"https://schema.org/FAQPage", "http://www.w3.org/2000/01/rdf-schema#comment", `A [[FAQPage]] is a [[WebPage]] presenting one or more "[Frequently asked questions](https://en.wikipedia.org/wiki/FAQ)" (see also [[QAPage]]).`},
 			{"https://schema.org/FAQPage", "http://www.w3.org/2000/01/rdf-schema#subClassOf", "https://schema.org/WebPage"},
 			{"https://schema.org/FAQPage", "https://schema.org/source", "https://github.com/schemaorg/schemaorg/issues/1723"},
 		},
@@ -635,11 +637,75 @@ var scanTestCases = map[string]struct {
 		expectedTriples: [][3]string{
 			{"https://schema.org/FAQPage", "http://www.w3.org/1999/02/22-rdf-syntax-ns#type", "http://www.w3.org/2000/01/rdf-schema#Class"},
 			{"https://schema.org/FAQPage", "http://www.w3.org/2000/01/rdf-schema#label", "FAQPage"},
-			{"https://schema.org/FAQPage", "http://www.w3.org/2000/01/rdf-schema#comment", `A [[FAQPage]] is a [[WebPage]] presenting one or more \'[Frequently asked questions](https://en.wikipedia.org/wiki/FAQ)\' (see also [[QAPage]]).`},
+			{"https://schema.org/FAQPage", "http://www.w3.org/2000/01/rdf-schema#comment", `A [[FAQPage]] is a [[WebPage]] presenting one or more '[Frequently asked questions](https://en.wikipedia.org/wiki/FAQ)' (see also [[QAPage]]).`},
 			{"https://schema.org/FAQPage", "http://www.w3.org/2000/01/rdf-schema#subClassOf", "https://schema.org/WebPage"},
 			{"https://schema.org/FAQPage", "https://schema.org/source", "https://github.com/schemaorg/schemaorg/issues/1723"},
 		},
 	},
+	"escape_sequences": {
+		data: []byte(`@prefix ex: <http://example.org/\u0065x/> .
+<http://example.org/a\u0020b> ex:path\/to\.item "back\\\\" ;
+	ex:text """tab\tnew\nline \"quoted\"""" ;
+	ex:name 'Spider\u002DMan \U0001F577' .`),
+		expectedTokens: []string{
+			"@prefix",
+			"ex:",
+			`<http://example.org/\u0065x/>`,
+			".",
+			`<http://example.org/a\u0020b>`,
+			`ex:path\/to\.item`,
+			`"back\\\\"`,
+			";",
+			"ex:text",
+			`"""tab\tnew\nline \"quoted\""""`,
+			";",
+			"ex:name",
+			`'Spider\u002DMan \U0001F577'`,
+			".",
+		},
+		expectedTriples: [][3]string{
+			{"http://example.org/a b", "http://example.org/ex/path/to.item", `back\\`},
+			{"http://example.org/a b", "http://example.org/ex/text", "tab\tnew\nline \"quoted\""},
+			{"http://example.org/a b", "http://example.org/ex/name", "Spider-Man 🕷"},
+		},
+	},
+	"relative_base": {
+		data: []byte(`@base <http://example.org/a/b/> .
+<c> <p> <../d> .
+@base <../x/> .
+<c> <p> <?q> .
+@prefix ex: <#> .
+ex:y <p> <//other.example/z> .`),
+		expectedTokens: []string{
+			"@base",
+			"<http://example.org/a/b/>",
+			".",
+			"<c>",
+			"<p>",
+			"<../d>",
+			".",
+			"@base",
+			"<../x/>",
+			".",
+			"<c>",
+			"<p>",
+			"<?q>",
+			".",
+			"@prefix",
+			"ex:",
+			"<#>",
+			".",
+			"ex:y",
+			"<p>",
+			"<//other.example/z>",
+			".",
+		},
+		expectedTriples: [][3]string{
+			{"http://example.org/a/b/c", "http://example.org/a/b/p", "http://example.org/a/d"},
+			{"http://example.org/a/x/c", "http://example.org/a/x/p", "http://example.org/a/x/?q"},
+			{"http://example.org/a/x/#y", "http://example.org/a/x/p", "http://other.example/z"},
+		},
+	},
 	"base_with_number_sign": {
 		data: []byte(`@base <http://example.org/stats#> .
 						<http://somecountry.example/census2007>
@@ -1455,3 +1521,174 @@ func TestNext(t *testing.T) {
 		})
 	}
 }
+
+func TestScanTurtleOneByteReader(t *testing.T) {
+	for name, tc := range scanTestCases {
+		t.Run(name, func(t *testing.T) {
+			s := bufio.NewScanner(iotest.OneByteReader(bytes.NewReader(tc.data)))
+			s.Split(splitTurtle)
+			actual := make([]string, 0)
+			for s.Scan() {
+				actual = append(actual, s.Text())
+			}
+			assert.Equal(t, tc.expectedTokens, actual, "scanTurtle should have created correct turtle tokens from partially read data")
+		})
+	}
+}
+
+func TestNextReader(t *testing.T) {
+	for name, tc := range scanTestCases {
+		t.Run(name, func(t *testing.T) {
+			s := NewReader(iotest.OneByteReader(bytes.NewReader(tc.data)))
+			actual := make([][3]string, 0)
+			for s.Next() {
+				actual = append(actual, s.Triple())
+			}
+			assert.Equal(t, tc.expectedTriples, actual, "scanner should have created correct turtle triples from a reader")
+			assert.NoError(t, s.Err(), "scanner should have scanned the data without an error")
+		})
+	}
+}
+
+func TestNextVerbatim(t *testing.T) {
+	data := []byte(`@base <http://example.org/> .
+@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+</people/green-goblin> a foaf:Person ; foaf:knows <http://example.org/people/spiderman> .`)
+
+	expected := [][3]string{
+		{"/people/green-goblin", rdfTypeIRI, "foaf:Person"},
+		{"/people/green-goblin", "foaf:knows", "http://example.org/people/spiderman"},
+	}
+
+	s := NewWithOptions(data, Options{Verbatim: true})
+
+	actual := make([][3]string, 0)
+	for s.Next() {
+		actual = append(actual, s.Triple())
+	}
+
+	assert.NoError(t, s.Err(), "scanner should have returned no error")
+	assert.Equal(t, expected, actual, "scanner should have kept the IRIs as written")
+}
+
+var graphTestCases = map[string]struct {
+	data     string
+	expected [][4]string
+}{
+	"graph_keyword": {
+		data: `@prefix ex: <http://example.org/> .
+ex:a ex:b ex:c .
+GRAPH ex:g { ex:a ex:b ex:d }
+ex:a ex:b ex:e .`,
+		expected: [][4]string{
+			{"http://example.org/a", "http://example.org/b", "http://example.org/c", ""},
+			{"http://example.org/a", "http://example.org/b", "http://example.org/d", "http://example.org/g"},
+			{"http://example.org/a", "http://example.org/b", "http://example.org/e", ""},
+		},
+	},
+	"graph_label": {
+		data: `<http://example.org/g> {
+	<http://example.org/a> <http://example.org/b> ( <http://example.org/c> ) ;
+		<http://example.org/d> [ <http://example.org/e> "f" ] .
+	<http://example.org/g> <http://example.org/b> "c"
+}`,
+		expected: [][4]string{
+			{"_:b0", rdfFirst, "http://example.org/c", "http://example.org/g"},
+			{"_:b0", rdfRest, rdfNil, "http://example.org/g"},
+			{"http://example.org/a", "http://example.org/b", "_:b0", "http://example.org/g"},
+			{"_:b1", "http://example.org/e", "f", "http://example.org/g"},
+			{"http://example.org/a", "http://example.org/d", "_:b1", "http://example.org/g"},
+			{"http://example.org/g", "http://example.org/b", "c", "http://example.org/g"},
+		},
+	},
+	"blank_node_labels": {
+		data: `_:g{<a> <b> <c>} graph [] { <a> <b> <d> . }`,
+		expected: [][4]string{
+			{"a", "b", "c", "_:g"},
+			{"a", "b", "d", "_:b0"},
+		},
+	},
+	"default_graph_block": {
+		data: `{ <a> <b> <c> } <d> { <a> <b> <c> }`,
+		expected: [][4]string{
+			{"a", "b", "c", ""},
+			{"a", "b", "c", "d"},
+		},
+	},
+}
+
+func TestGraph(t *testing.T) {
+	for name, tc := range graphTestCases {
+		t.Run(name, func(t *testing.T) {
+			for _, options := range []Options{{TriG: true}, {Strict: true, TriG: true}} {
+				s := NewWithOptions([]byte(tc.data), options)
+
+				actual := make([][4]string, 0)
+				for s.Next() {
+					triple := s.Triple()
+					actual = append(actual, [4]string{triple[0], triple[1], triple[2], s.Graph()})
+				}
+
+				assert.NoError(t, s.Err(), "scanner should have returned no error")
+				assert.Equal(t, tc.expected, actual, "scanner should have returned the graphs of the triples")
+				assert.Equal(t, "", s.Graph(), "scanner should have returned no graph at the end")
+			}
+		})
+	}
+}
+
+var quotedTripleTestCases = map[string]struct {
+	data     string
+	expected [][6]string
+}{
+	"quoted_subject": {
+		data: `@prefix ex: <http://example.org/> .
+<< ex:alice ex:age 30 >> ex:confidence 0.9 .`,
+		expected: [][6]string{
+			{`<< <http://example.org/alice> <http://example.org/age> "30"^^<http://www.w3.org/2001/XMLSchema#integer> >>`, "http://example.org/confidence", "0.9", "", rdf.XSDDecimal, "literal"},
+		},
+	},
+	"quoted_object_without_spaces": {
+		data: `<http://example.org/bob> <http://example.org/says> <<<http://example.org/alice> a _:b>>.`,
+		expected: [][6]string{
+			{"http://example.org/bob", "http://example.org/says", "<< <http://example.org/alice> <" + rdfTypeIRI + "> _:b >>", "", "", "triple"},
+		},
+	},
+	"nested": {
+		data: `<< << <a> <b> "c"@en >> <d> [] >> <e> ( << <f> <g> <h> >> ) .`,
+		expected: [][6]string{
+			{"_:b1", rdfFirst, `<< <f> <g> <h> >>`, "", "", "triple"},
+			{"_:b1", rdfRest, rdfNil, "", "", "iri"},
+			{`<< << <a> <b> "c"@en >> <d> _:b0 >>`, "e", "_:b1", "", "", "iri"},
+		},
+	},
+	"annotation": {
+		data: `@prefix ex: <http://example.org/> .
+ex:alice ex:knows ex:bob {| ex:source ex:census ; ex:since "2020" {| ex:confidence 0.5 |} |}, ex:carol .`,
+		expected: [][6]string{
+			{"http://example.org/alice", "http://example.org/knows", "http://example.org/bob", "", "", "iri"},
+			{"<< <http://example.org/alice> <http://example.org/knows> <http://example.org/bob> >>", "http://example.org/source", "http://example.org/census", "", "", "iri"},
+			{"<< <http://example.org/alice> <http://example.org/knows> <http://example.org/bob> >>", "http://example.org/since", "2020", "", "", "literal"},
+			{`<< << <http://example.org/alice> <http://example.org/knows> <http://example.org/bob> >> <http://example.org/since> "2020" >>`, "http://example.org/confidence", "0.5", "", rdf.XSDDecimal, "literal"},
+			{"http://example.org/alice", "http://example.org/knows", "http://example.org/carol", "", "", "iri"},
+		},
+	},
+}
+
+func TestQuotedTriple(t *testing.T) {
+	for name, tc := range quotedTripleTestCases {
+		t.Run(name, func(t *testing.T) {
+			for _, options := range []Options{{}, {Strict: true}} {
+				s := NewReaderWithOptions(iotest.OneByteReader(bytes.NewReader([]byte(tc.data))), options)
+
+				actual := make([][6]string, 0)
+				for s.Next() {
+					actual = append(actual, s.TripleWithAnnotations())
+				}
+
+				assert.NoError(t, s.Err(), "scanner should have returned no error")
+				assert.Equal(t, tc.expected, actual, "scanner should have read the quoted triples as single terms")
+			}
+		})
+	}
+}
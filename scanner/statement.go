@@ -0,0 +1,86 @@
+package scanner
+
+import (
+	"iter"
+	"strings"
+
+	"github.com/nvkp/turtle/rdf"
+)
+
+// typeTriple is the type of the quoted triple terms, which are
+// held in their N-Triples form, e.g. << <a> <b> "c" >>.
+const typeTriple = "triple"
+
+// Statement returns the next triple made of RDF terms. The triple
+// is empty if there is none.
+func (s *Scanner) Statement() rdf.Triple {
+	t := s.TripleWithAnnotations()
+	if t[0] == "" {
+		return rdf.Triple{}
+	}
+
+	return rdf.Triple{
+		Subject:   rdf.Resource(t[0]),
+		Predicate: rdf.IRI(t[1]),
+		Object:    object(t),
+	}
+}
+
+// Quad returns the next triple made of RDF terms together with the name
+// of its graph, which is nil for the triples of the default graph.
+func (s *Scanner) Quad() rdf.Quad {
+	t := s.Statement()
+	q := rdf.Quad{Subject: t.Subject, Predicate: t.Predicate, Object: t.Object}
+	if name := s.Graph(); name != "" {
+		q.Graph = rdf.Resource(name)
+	}
+
+	return q
+}
+
+// All returns an iterator over the triples made of RDF terms that are
+// left in the data, the same ones Statement returns after every call
+// of Next. The iteration stops at the first error, which is yielded
+// together with an empty triple. During the iteration Graph returns
+// the name of the graph of the yielded triple.
+func (s *Scanner) All() iter.Seq2[rdf.Triple, error] {
+	return func(yield func(rdf.Triple, error) bool) {
+		for s.Next() {
+			if !yield(s.Statement(), nil) {
+				return
+			}
+		}
+
+		if err := s.Err(); err != nil {
+			yield(rdf.Triple{}, err)
+		}
+	}
+}
+
+func object(t [6]string) rdf.Term {
+	if t[5] == "literal" {
+		lang, direction := rdf.SplitLanguageTag(t[3])
+		return rdf.Literal{Value: t[2], Lang: lang, Direction: direction, Datatype: rdf.IRI(t[4])}
+	}
+
+	return rdf.Resource(t[2])
+}
+
+// quote returns the sanitized term in the N-Triples form
+// it has as a part of a quoted triple.
+func quote(token, label, datatype, typ string) string {
+	switch {
+	case rdf.IsQuotedTriple(token):
+		return token
+	case typ == "literal":
+		lang, direction := rdf.SplitLanguageTag(label)
+		return rdf.Literal{Value: token, Lang: lang, Direction: direction, Datatype: rdf.IRI(datatype)}.String()
+	}
+
+	return rdf.Resource(token).String()
+}
+
+// quoteTriple returns the quoted triple of the terms in the N-Triples form.
+func quoteTriple(terms []string) string {
+	return "<< " + strings.Join(terms, " ") + " >>"
+}
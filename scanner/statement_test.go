@@ -0,0 +1,113 @@
+package scanner_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nvkp/turtle/assert"
+	"github.com/nvkp/turtle/rdf"
+	"github.com/nvkp/turtle/scanner"
+)
+
+func TestStatement(t *testing.T) {
+	data := []byte(`@prefix ex: <http://example.org/> .
+ex:alice ex:name "Alice"@en, "أليس"@AR--rtl ;
+	ex:age 30 ;
+	ex:knows [ ex:name "Bob" ] .`)
+	expected := []rdf.Triple{
+		{Subject: rdf.IRI("http://example.org/alice"), Predicate: rdf.IRI("http://example.org/name"), Object: rdf.Literal{Value: "Alice", Lang: "en"}},
+		{Subject: rdf.IRI("http://example.org/alice"), Predicate: rdf.IRI("http://example.org/name"), Object: rdf.Literal{Value: "أليس", Lang: "ar", Direction: rdf.DirectionRTL}},
+		{Subject: rdf.IRI("http://example.org/alice"), Predicate: rdf.IRI("http://example.org/age"), Object: rdf.Literal{Value: "30", Datatype: "http://www.w3.org/2001/XMLSchema#integer"}},
+		{Subject: rdf.BlankNode("b0"), Predicate: rdf.IRI("http://example.org/name"), Object: rdf.Literal{Value: "Bob"}},
+		{Subject: rdf.IRI("http://example.org/alice"), Predicate: rdf.IRI("http://example.org/knows"), Object: rdf.BlankNode("b0")},
+	}
+
+	s := scanner.New(data)
+
+	var actual []rdf.Triple
+	for s.Next() {
+		actual = append(actual, s.Statement())
+	}
+
+	assert.NoError(t, s.Err(), "scanner should have returned no error")
+	assert.Equal(t, expected, actual, "scanner should have returned the triples made of RDF terms")
+}
+
+func TestQuad(t *testing.T) {
+	data := []byte(`@prefix ex: <http://example.org/> .
+ex:alice ex:name "Alice" .
+ex:g { ex:alice ex:knows _:bob }`)
+	expected := []rdf.Quad{
+		{Subject: rdf.IRI("http://example.org/alice"), Predicate: rdf.IRI("http://example.org/name"), Object: rdf.Literal{Value: "Alice"}},
+		{Subject: rdf.IRI("http://example.org/alice"), Predicate: rdf.IRI("http://example.org/knows"), Object: rdf.BlankNode("bob"), Graph: rdf.IRI("http://example.org/g")},
+	}
+
+	s := scanner.NewWithOptions(data, scanner.Options{TriG: true})
+
+	var actual []rdf.Quad
+	for s.Next() {
+		actual = append(actual, s.Quad())
+	}
+
+	assert.NoError(t, s.Err(), "scanner should have returned no error")
+	assert.Equal(t, expected, actual, "scanner should have returned the triples together with their graphs")
+}
+
+func TestStatementQuotedTriple(t *testing.T) {
+	data := []byte(`@prefix ex: <http://example.org/> .
+ex:alice ex:knows ex:bob {| ex:source << ex:census ex:year 2020 >> |} .`)
+	knows := rdf.QuotedTriple{Subject: rdf.IRI("http://example.org/alice"), Predicate: rdf.IRI("http://example.org/knows"), Object: rdf.IRI("http://example.org/bob")}
+	expected := []rdf.Triple{
+		rdf.Triple(knows),
+		{Subject: knows, Predicate: rdf.IRI("http://example.org/source"), Object: rdf.QuotedTriple{
+			Subject:   rdf.IRI("http://example.org/census"),
+			Predicate: rdf.IRI("http://example.org/year"),
+			Object:    rdf.Literal{Value: "2020", Datatype: "http://www.w3.org/2001/XMLSchema#integer"},
+		}},
+	}
+
+	s := scanner.New(data)
+
+	var actual []rdf.Triple
+	for s.Next() {
+		actual = append(actual, s.Statement())
+	}
+
+	assert.NoError(t, s.Err(), "scanner should have returned no error")
+	assert.Equal(t, expected, actual, "scanner should have returned the quoted triples as terms")
+}
+
+func TestAll(t *testing.T) {
+	data := []byte(`@prefix ex: <http://example.org/> .
+ex:alice ex:name "Alice" ; ex:age 30 .
+ex:bob ex:name "Bob" .
+ex:carol ; ex:name "Carol" .`)
+	expected := []rdf.Triple{
+		{Subject: rdf.IRI("http://example.org/alice"), Predicate: rdf.IRI("http://example.org/name"), Object: rdf.Literal{Value: "Alice"}},
+		{Subject: rdf.IRI("http://example.org/alice"), Predicate: rdf.IRI("http://example.org/age"), Object: rdf.Literal{Value: "30", Datatype: "http://www.w3.org/2001/XMLSchema#integer"}},
+		{Subject: rdf.IRI("http://example.org/bob"), Predicate: rdf.IRI("http://example.org/name"), Object: rdf.Literal{Value: "Bob"}},
+	}
+
+	var actual []rdf.Triple
+	var errs []error
+	for triple, err := range scanner.New(data).All() {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		actual = append(actual, triple)
+	}
+
+	assert.Equal(t, expected, actual, "scanner should have yielded the triples")
+	assert.Equal(t, 1, len(errs), "scanner should have yielded the error once")
+
+	var syntaxErr *scanner.SyntaxError
+	assert.Equal(t, true, errors.As(errs[0], &syntaxErr), "scanner should have yielded a syntax error")
+
+	var count int
+	for range scanner.New(data).All() {
+		count++
+		break
+	}
+	assert.Equal(t, 1, count, "scanner should have stopped yielding the triples")
+}
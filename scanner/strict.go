@@ -0,0 +1,110 @@
+package scanner
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/nvkp/turtle/rdf"
+)
+
+// productions of the Turtle grammar as defined by https://www.w3.org/TR/turtle/#sec-grammar-grammar
+const (
+	pnCharsU = rdf.PNCharsBase + `_`
+	pnChars  = pnCharsU + `\-0-9\x{00B7}\x{0300}-\x{036F}\x{203F}-\x{2040}`
+	plx      = `%[0-9A-Fa-f]{2}|\\[_~.\-!$&'()*+,;=/?#@%]`
+	pnPrefix = `[` + rdf.PNCharsBase + `](?:[` + pnChars + `.]*[` + pnChars + `])?`
+	pnLocal  = `(?:[` + pnCharsU + `:0-9]|` + plx + `)(?:(?:[` + pnChars + `.:]|` + plx + `)*(?:[` + pnChars + `:]|` + plx + `))?`
+	uchar    = `\\u[0-9A-Fa-f]{4}|\\U[0-9A-Fa-f]{8}`
+	echar    = `\\[tbnrf"'\\]`
+	iriRef   = `<(?:[^\x00-\x20<>"{}|^` + "`" + `\\]|` + uchar + `)*>`
+	langTag  = `@([^\s]+)`
+
+	stringLiteralQuote           = `"(?:[^"\\\n\r]|` + echar + `|` + uchar + `)*"`
+	stringLiteralSingleQuote     = `'(?:[^'\\\n\r]|` + echar + `|` + uchar + `)*'`
+	stringLiteralLongQuote       = `"""(?:(?:"|"")?(?:[^"\\]|` + echar + `|` + uchar + `))*"""`
+	stringLiteralLongSingleQuote = `'''(?:(?:'|'')?(?:[^'\\]|` + echar + `|` + uchar + `))*'''`
+)
+
+var (
+	regexIRIRef        = regexp.MustCompile(`^` + iriRef + `$`)
+	regexPrefixName    = regexp.MustCompile(`^(?:` + pnPrefix + `)?:$`)
+	regexPrefixedName  = regexp.MustCompile(`^((?:` + pnPrefix + `)?):(?:` + pnLocal + `)?$`)
+	regexBlankNodeName = regexp.MustCompile(`^_:[` + pnCharsU + `0-9](?:[` + pnChars + `.]*[` + pnChars + `])?$`)
+	regexNumeric       = regexp.MustCompile(`^[+-]?(?:[0-9]+(?:\.[0-9]*)?[eE][+-]?[0-9]+|\.[0-9]+[eE][+-]?[0-9]+|[0-9]*\.[0-9]+|[0-9]+)$`)
+	regexLiteral       = regexp.MustCompile(`^(?s:` + stringLiteralLongQuote + `|` + stringLiteralLongSingleQuote + `|` +
+		stringLiteralQuote + `|` + stringLiteralSingleQuote + `)(?:` + langTag + `|\^\^(.+))?$`)
+)
+
+// positions of a term in a triple
+const (
+	positionSubject = iota
+	positionPredicate
+	positionObject
+)
+
+// validate checks that the token is a term allowed by the Turtle grammar
+// at the given position of a triple. It returns a description of what
+// was expected instead when it is not.
+func (s *Scanner) validate(token string, position int) string {
+	expected := [...]string{
+		positionSubject:   "IRI or blank node",
+		positionPredicate: "IRI",
+		positionObject:    "IRI, blank node or literal",
+	}[position]
+
+	switch {
+	case rdf.IsQuotedTriple(token):
+		if position == positionPredicate {
+			return expected
+		}
+		return ""
+	case regexIRIRef.MatchString(token):
+		return ""
+	case regexPrefixedName.MatchString(token):
+		return s.validatePrefix(token)
+	case token == "a":
+		if position != positionPredicate {
+			return expected
+		}
+		return ""
+	case position == positionPredicate:
+		return expected
+	case regexBlankNodeName.MatchString(token):
+		return ""
+	case position == positionSubject:
+		return expected
+	case token == "true" || token == "false" || regexNumeric.MatchString(token):
+		return ""
+	}
+
+	match := regexLiteral.FindStringSubmatch(token)
+	if match == nil {
+		return expected
+	}
+
+	if _, err := rdf.NormalizeLanguageTag(match[1]); match[1] != "" && err != nil {
+		return "language tag"
+	}
+
+	datatype := match[2]
+	switch {
+	case datatype == "":
+		return ""
+	case regexIRIRef.MatchString(datatype):
+		return ""
+	case regexPrefixedName.MatchString(datatype):
+		return s.validatePrefix(datatype)
+	}
+
+	return "datatype IRI"
+}
+
+// validatePrefix checks that the prefix of the prefixed name was declared.
+func (s *Scanner) validatePrefix(name string) string {
+	prefix, _, _ := strings.Cut(name, ":")
+	if _, ok := s.prefixes[prefix]; !ok {
+		return "declared prefix"
+	}
+
+	return ""
+}
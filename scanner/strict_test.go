@@ -0,0 +1,251 @@
+package scanner
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nvkp/turtle/assert"
+)
+
+func TestStrict(t *testing.T) {
+	data := []byte(`@base <http://example.org/> .
+@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+PREFIX stats: <http://example.org/stats>
+
+<#green-goblin> a foaf:Person ;
+	foaf:name "Green Goblin"@en, 'Zelený Goblin'@CS, "غول أخضر"@ar--rtl ;
+	foaf:age 42 ;
+	stats:isVillain true ;
+	foaf:knows [ foaf:name """Spider
+man""" ; ] ;
+	foaf:interest ( <#crime> 1.5 -2E3 ) ;
+.
+[ foaf:name "Nobody"^^<http://www.w3.org/2001/XMLSchema#string> ] .
+_:someone foaf:knows [] .`)
+
+	expected := [][3]string{
+		{"http://example.org/#green-goblin", rdfTypeIRI, "http://xmlns.com/foaf/0.1/Person"},
+		{"http://example.org/#green-goblin", "http://xmlns.com/foaf/0.1/name", "Green Goblin"},
+		{"http://example.org/#green-goblin", "http://xmlns.com/foaf/0.1/name", "Zelený Goblin"},
+		{"http://example.org/#green-goblin", "http://xmlns.com/foaf/0.1/name", "غول أخضر"},
+		{"http://example.org/#green-goblin", "http://xmlns.com/foaf/0.1/age", "42"},
+		{"http://example.org/#green-goblin", "http://example.org/statsisVillain", "true"},
+		{"_:b0", "http://xmlns.com/foaf/0.1/name", "Spider\nman"},
+		{"http://example.org/#green-goblin", "http://xmlns.com/foaf/0.1/knows", "_:b0"},
+		{"_:b1", rdfFirst, "http://example.org/#crime"},
+		{"_:b1", rdfRest, "_:b2"},
+		{"_:b2", rdfFirst, "1.5"},
+		{"_:b2", rdfRest, "_:b3"},
+		{"_:b3", rdfFirst, "-2E3"},
+		{"_:b3", rdfRest, rdfNil},
+		{"http://example.org/#green-goblin", "http://xmlns.com/foaf/0.1/interest", "_:b1"},
+		{"_:b4", "http://xmlns.com/foaf/0.1/name", "Nobody"},
+		{"_:someone", "http://xmlns.com/foaf/0.1/knows", "_:b5"},
+	}
+
+	s := NewWithOptions(data, Options{Strict: true})
+
+	actual := make([][3]string, 0)
+	for s.Next() {
+		actual = append(actual, s.Triple())
+	}
+
+	assert.NoError(t, s.Err(), "strict scanner should have accepted valid data")
+	assert.Equal(t, expected, actual, "strict scanner should have created correct triples")
+}
+
+var strictErrorTestCases = map[string]struct {
+	data     string
+	trig     bool
+	token    string
+	expected string
+}{
+	"missing_full_stop": {
+		data:     "<a> <b> <c>\n<d> <e> <f> .",
+		token:    "<d>",
+		expected: `";", "," or "."`,
+	},
+	"missing_full_stop_at_end": {
+		data:     "<a> <b> <c>",
+		expected: `"."`,
+	},
+	"missing_full_stop_after_prefix": {
+		data:     "@prefix foaf: <http://xmlns.com/foaf/0.1/>\n<a> <b> <c> .",
+		token:    "<a>",
+		expected: `"."`,
+	},
+	"full_stop_after_sparql_prefix": {
+		data:     "PREFIX foaf: <http://xmlns.com/foaf/0.1/> .\n<a> <b> <c> .",
+		token:    ".",
+		expected: "subject",
+	},
+	"comma_after_semicolon": {
+		data:     "<a> <b> <c> ; , <d> .",
+		token:    ",",
+		expected: `";", "," or "."`,
+	},
+	"bare_word_object": {
+		data:     "<a> <b> word .",
+		token:    "word",
+		expected: "IRI, blank node or literal",
+	},
+	"literal_subject": {
+		data:     `"a" <b> <c> .`,
+		token:    `"a"`,
+		expected: "IRI or blank node",
+	},
+	"literal_predicate": {
+		data:     `<a> "b" <c> .`,
+		token:    `"b"`,
+		expected: "IRI",
+	},
+	"keyword_a_as_object": {
+		data:     "<a> <b> a .",
+		token:    "a",
+		expected: "IRI, blank node or literal",
+	},
+	"undeclared_prefix": {
+		data:     "<a> foaf:name <c> .",
+		token:    "foaf:name",
+		expected: "declared prefix",
+	},
+	"undeclared_datatype_prefix": {
+		data:     `<a> <b> "c"^^xsd:string .`,
+		token:    `"c"^^xsd:string`,
+		expected: "declared prefix",
+	},
+	"invalid_iri_character": {
+		data:     "<a> <b> <c|d> .",
+		token:    "<c|d>",
+		expected: "IRI, blank node or literal",
+	},
+	"blank_node_list_predicate": {
+		data:     "<a> [ <b> <c> ] <d> .",
+		token:    "[",
+		expected: "predicate",
+	},
+	"empty_blank_node_list_alone": {
+		data:     "[] .",
+		token:    ".",
+		expected: "predicate",
+	},
+	"invalid_prefix_name": {
+		data:     "@prefix 1foaf: <http://xmlns.com/foaf/0.1/> .",
+		token:    "1foaf:",
+		expected: "prefix name",
+	},
+	"directive_inside_statement": {
+		data:     "<a> @prefix foaf: <http://xmlns.com/foaf/0.1/> .",
+		token:    "@prefix",
+		expected: "predicate",
+	},
+	"prefix_in_graph_block": {
+		data:     "<g> { @prefix ex: <http://example.org/> . }",
+		trig:     true,
+		token:    "@prefix",
+		expected: `subject or "}"`,
+	},
+	"literal_graph_name": {
+		data:     `"g" { <a> <b> <c> }`,
+		trig:     true,
+		token:    `"g"`,
+		expected: "IRI or blank node",
+	},
+	"invalid_language_tag": {
+		data:     `<a> <b> "c"@en-abcdefghi .`,
+		token:    `"c"@en-abcdefghi`,
+		expected: "language tag",
+	},
+	"invalid_direction": {
+		data:     `<a> <b> "c"@ar--up .`,
+		token:    `"c"@ar--up`,
+		expected: "language tag",
+	},
+	"language_tag_starting_with_digit": {
+		data:     `<a> <b> "x"@123abc .`,
+		token:    `"x"@123abc`,
+		expected: "language tag",
+	},
+	"language_tag_trailing_delimiter": {
+		data:     `<a> <b> "x"@en-- .`,
+		token:    `"x"@en--`,
+		expected: "language tag",
+	},
+	"graph_block_in_turtle": {
+		data:     "<g> { <a> <b> <c> }",
+		token:    "{",
+		expected: "predicate",
+	},
+	"default_graph_block_in_turtle": {
+		data:     "{ <a> <b> <c> }",
+		token:    "{",
+		expected: "subject",
+	},
+	"graph_keyword_in_turtle": {
+		data:     "GRAPH <g> { <a> <b> <c> }",
+		token:    "GRAPH",
+		expected: "IRI or blank node",
+	},
+	"comma_in_collection": {
+		data:     "<a> <b> ( <c> , <d> ) .",
+		token:    ",",
+		expected: `object or ")"`,
+	},
+}
+
+func TestStrictErrors(t *testing.T) {
+	for name, tc := range strictErrorTestCases {
+		t.Run(name, func(t *testing.T) {
+			s := NewWithOptions([]byte(tc.data), Options{Strict: true, TriG: tc.trig})
+			for s.Next() {
+			}
+
+			var syntaxErr *SyntaxError
+			if !errors.As(s.Err(), &syntaxErr) {
+				t.Fatalf("expected a syntax error, got: %v", s.Err())
+			}
+
+			assert.Equal(t, tc.token, syntaxErr.Token, "strict scanner should have stopped at the correct token")
+			assert.Equal(t, tc.expected, syntaxErr.Expected, "strict scanner should have described what was expected")
+		})
+	}
+}
+
+var strictFullStopTestCases = map[string]struct {
+	data     string
+	expected [][3]string
+}{
+	"inner_full_stop": {
+		data:     "ex:a ex:b ex:c.d .",
+		expected: [][3]string{{"http://example.org/a", "http://example.org/b", "http://example.org/c.d"}},
+	},
+	"inner_full_stop_before_terminator": {
+		data:     "ex:a ex:b ex:c.d.",
+		expected: [][3]string{{"http://example.org/a", "http://example.org/b", "http://example.org/c.d"}},
+	},
+	"inner_full_stops": {
+		data:     "ex:a.b ex:b ex:c..d.\n",
+		expected: [][3]string{{"http://example.org/a.b", "http://example.org/b", "http://example.org/c..d"}},
+	},
+	"blank_node_label": {
+		data:     "_:a.b ex:b ex:c.# comment",
+		expected: [][3]string{{"_:a.b", "http://example.org/b", "http://example.org/c"}},
+	},
+}
+
+func TestStrictFullStops(t *testing.T) {
+	for name, tc := range strictFullStopTestCases {
+		t.Run(name, func(t *testing.T) {
+			data := []byte("@prefix ex: <http://example.org/> .\n" + tc.data)
+			s := NewWithOptions(data, Options{Strict: true})
+
+			actual := make([][3]string, 0)
+			for s.Next() {
+				actual = append(actual, s.Triple())
+			}
+
+			assert.NoError(t, s.Err(), "strict scanner should have accepted the full stops inside of names")
+			assert.Equal(t, tc.expected, actual, "strict scanner should have ended the names at the last full stop")
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package turtle
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/nvkp/turtle/graph"
+	"github.com/nvkp/turtle/ntriples"
+	"github.com/nvkp/turtle/scanner"
+)
+
+// source reads the triples of the unmarshalled data one by one.
+// It is implemented by scanner.Scanner, reading Turtle and TriG,
+// and ntriplesSource.
+type source interface {
+	Next() bool
+	TripleWithAnnotations() [6]string
+	Err() error
+	Prefixes() map[string]string
+	Base() string
+}
+
+// ntriplesSource reads N-Triples or N-Quads data. As the data has
+// no @base and @prefix forms, the configured ones are returned.
+type ntriplesSource struct {
+	*ntriples.Reader
+	base     string
+	prefixes map[string]string
+}
+
+func (s *ntriplesSource) Prefixes() map[string]string {
+	return s.prefixes
+}
+
+func (s *ntriplesSource) Base() string {
+	return s.base
+}
+
+// source returns the reader of the data in the configured format.
+func (c *Config) source(data []byte) source {
+	switch c.Format {
+	case graph.FormatNTriples, graph.FormatNQuads:
+		return c.readerSource(bytes.NewReader(data))
+	}
+
+	return scanner.NewWithOptions(data, c.scannerOptions())
+}
+
+// readerSource returns the reader of the stream in the configured format.
+func (c *Config) readerSource(r io.Reader) source {
+	switch c.Format {
+	case graph.FormatNTriples:
+		return &ntriplesSource{Reader: ntriples.NewReader(r), base: c.Base, prefixes: c.prefixes()}
+	case graph.FormatNQuads:
+		return &ntriplesSource{Reader: ntriples.NewQuadReader(r), base: c.Base, prefixes: c.prefixes()}
+	}
+
+	return scanner.NewReaderWithOptions(r, c.scannerOptions())
+}
+
+// graphName returns the name of the graph of the current triple,
+// an empty string for the default graph or if the source has no graphs.
+func graphName(s source) string {
+	if g, ok := s.(interface{ Graph() string }); ok {
+		return g.Graph()
+	}
+
+	return ""
+}
@@ -0,0 +1,108 @@
+package turtle_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/nvkp/turtle"
+	"github.com/nvkp/turtle/assert"
+	"github.com/nvkp/turtle/graph"
+)
+
+type quad struct {
+	Subject   string `turtle:"subject"`
+	Predicate string `turtle:"predicate"`
+	Object    string `turtle:"object"`
+	Graph     string `turtle:"graph"`
+}
+
+var quads = []quad{
+	{Subject: "http://example.org/alice", Predicate: "http://xmlns.com/foaf/0.1/name", Object: "Alice"},
+	{Subject: "http://example.org/alice", Predicate: "http://xmlns.com/foaf/0.1/age", Object: "30", Graph: "http://example.org/source/1"},
+	{Subject: "http://example.org/bob", Predicate: "http://xmlns.com/foaf/0.1/name", Object: "Bob", Graph: "_:source"},
+}
+
+const quadsData = `<http://example.org/alice> <http://xmlns.com/foaf/0.1/name> "Alice" .
+_:source <http://xmlns.com/foaf/0.1/name> "Bob" _:source .
+<http://example.org/alice> <http://xmlns.com/foaf/0.1/age> "30" <http://example.org/source/1> .
+`
+
+func TestMarshalNQuads(t *testing.T) {
+	config := turtle.Config{Format: graph.FormatNQuads}
+
+	b, err := config.Marshal(quads)
+	assert.NoError(t, err, "Marshal function should have returned no error")
+	assert.Equal(t, `<http://example.org/alice> <http://xmlns.com/foaf/0.1/name> "Alice" .
+<http://example.org/bob> <http://xmlns.com/foaf/0.1/name> "Bob" _:source .
+<http://example.org/alice> <http://xmlns.com/foaf/0.1/age> "30" <http://example.org/source/1> .
+`, string(b), "Marshal function should have written the graph names")
+
+	var actual []quad
+	err = config.Unmarshal(b, &actual)
+	assert.NoError(t, err, "Unmarshal function should have returned no error")
+	assert.Equal(t, []quad{quads[0], quads[2], quads[1]}, actual, "Unmarshal function should have read the graph names")
+}
+
+func TestMarshalNamedGraphs(t *testing.T) {
+	_, err := turtle.Marshal(quads)
+	assert.ErrorIs(t, err, graph.ErrNamedGraphs, "Marshal function should not write named graphs as Turtle")
+
+	b, err := turtle.Marshal(quads[:1])
+	assert.NoError(t, err, "Marshal function should have returned no error")
+	assert.Equal(t, "<http://example.org/alice> <http://xmlns.com/foaf/0.1/name> \"Alice\" .\n", string(b), "Marshal function should have written the default graph")
+}
+
+func TestDecoderNQuads(t *testing.T) {
+	config := turtle.Config{Format: graph.FormatNQuads}
+	d := config.NewDecoder(bytes.NewReader([]byte(quadsData)))
+
+	var actual []quad
+	for {
+		var q quad
+		err := d.Decode(&q)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		assert.NoError(t, err, "Decode should have returned no error")
+		actual = append(actual, q)
+	}
+
+	assert.Equal(t, []quad{
+		quads[0],
+		{Subject: "_:source", Predicate: "http://xmlns.com/foaf/0.1/name", Object: "Bob", Graph: "_:source"},
+		quads[1],
+	}, actual, "Decode should have read the graph names")
+
+	var empty []quad
+	err := (&turtle.Config{Format: graph.FormatNTriples}).Unmarshal([]byte(quadsData), &empty)
+	var syntaxErr *turtle.SyntaxError
+	assert.Equal(t, true, errors.As(err, &syntaxErr), "N-Triples should not have graph names")
+}
+
+func TestMarshalTriG(t *testing.T) {
+	config := turtle.Config{
+		ResolveURLs: true,
+		Prefixes:    map[string]string{"ex": "http://example.org/", "foaf": "http://xmlns.com/foaf/0.1/"},
+		Format:      graph.FormatTriG,
+	}
+
+	b, err := config.Marshal(quads)
+	assert.NoError(t, err, "Marshal function should have returned no error")
+	assert.Equal(t, `@prefix ex: <http://example.org/> .
+@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+ex:alice foaf:name "Alice" .
+_:source {
+ex:bob foaf:name "Bob" .
+}
+ex:source\/1 {
+ex:alice foaf:age "30" .
+}
+`, string(b), "Marshal function should have written the named graphs in blocks")
+
+	var actual []quad
+	err = config.Unmarshal(b, &actual)
+	assert.NoError(t, err, "Unmarshal function should have returned no error")
+	assert.Equal(t, []quad{quads[0], quads[2], quads[1]}, actual, "Unmarshal function should have read the graph names")
+}
@@ -0,0 +1,37 @@
+package turtle
+
+import (
+	"io"
+	"iter"
+)
+
+// Triples returns an iterator over the triples read from r. Like the
+// Decoder it reads the input only as far as it is needed for the next
+// triple. The iteration stops at the first error, which is yielded
+// together with an empty triple. The input can be iterated over once.
+func Triples(r io.Reader) iter.Seq2[Triple, error] {
+	return (&Config{ResolveURLs: true}).Triples(r)
+}
+
+// Triples returns an iterator over the triples read from r
+// in the configured format with the configured base and prefixes.
+func (c *Config) Triples(r io.Reader) iter.Seq2[Triple, error] {
+	return func(yield func(Triple, error) bool) {
+		s := c.readerSource(r)
+		for s.Next() {
+			t := s.TripleWithAnnotations()
+			// skip the pragmas
+			if t[subject] == "" {
+				continue
+			}
+
+			if !yield(newTriple(t), nil) {
+				return
+			}
+		}
+
+		if err := s.Err(); err != nil {
+			yield(Triple{}, err)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package turtle_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/nvkp/turtle"
+	"github.com/nvkp/turtle/assert"
+	"github.com/nvkp/turtle/graph"
+)
+
+func TestTriples(t *testing.T) {
+	data := `@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+<http://example.org/alice> foaf:name "Alice"@en ;
+	foaf:age 30 .
+<http://example.org/bob> foaf:`
+	expected := []turtle.Triple{
+		{Subject: "http://example.org/alice", Predicate: "http://xmlns.com/foaf/0.1/name", Object: "Alice", Label: "en", ObjectType: turtle.TypeLiteral},
+		{Subject: "http://example.org/alice", Predicate: "http://xmlns.com/foaf/0.1/age", Object: "30", Datatype: "http://www.w3.org/2001/XMLSchema#integer", ObjectType: turtle.TypeLiteral},
+	}
+
+	var actual []turtle.Triple
+	var errs []error
+	for triple, err := range turtle.Triples(iotest.OneByteReader(strings.NewReader(data))) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		actual = append(actual, triple)
+	}
+
+	assert.Equal(t, expected, actual, "function Triples should have yielded the triples")
+	assert.Equal(t, 1, len(errs), "function Triples should have yielded the error once")
+
+	var syntaxErr *turtle.SyntaxError
+	assert.Equal(t, true, errors.As(errs[0], &syntaxErr), "function Triples should have yielded a syntax error")
+}
+
+func TestTriplesFilter(t *testing.T) {
+	data := `<http://example.org/alice> <http://xmlns.com/foaf/0.1/name> "Alice" .
+<http://example.org/alice> <http://xmlns.com/foaf/0.1/age> "30"^^<http://www.w3.org/2001/XMLSchema#integer> .
+<http://example.org/bob> <http://xmlns.com/foaf/0.1/name> "Bob" .
+`
+	config := turtle.Config{Format: graph.FormatNTriples}
+
+	var names []string
+	for triple, err := range config.Triples(strings.NewReader(data)) {
+		assert.NoError(t, err, "method Triples should have yielded no error")
+		if triple.Predicate != "http://xmlns.com/foaf/0.1/name" {
+			continue
+		}
+		names = append(names, triple.Object)
+		if len(names) == 1 {
+			break
+		}
+	}
+
+	assert.Equal(t, []string{"Alice"}, names, "method Triples should have stopped yielding the triples")
+}
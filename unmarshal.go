@@ -4,6 +4,7 @@ import (
 	"errors"
 	"reflect"
 
+	"github.com/nvkp/turtle/rdf"
 	"github.com/nvkp/turtle/scanner"
 )
 
@@ -12,6 +13,8 @@ const (
 	TypeIRI = "iri"
 	// TypeLiteral is for `turtle:"objecttype"` and indicates the object is a Literal.
 	TypeLiteral = "literal"
+	// TypeTriple is for `turtle:"objecttype"` and indicates the object is a quoted triple.
+	TypeTriple = "triple"
 )
 
 const (
@@ -23,6 +26,10 @@ const (
 	objecttype
 )
 
+// SyntaxError describes a malformed part of the parsed Turtle data together
+// with its line, column and byte offset. Unmarshal returns it wrapped.
+type SyntaxError = scanner.SyntaxError
+
 var (
 	// ErrNoPointerValue is returned by Unmarshal function when the passed value is not a pointer
 	ErrNoPointerValue = errors.New("value not a pointer")
@@ -44,19 +51,33 @@ var (
 // comments, labels and data types. The keyword a gets
 // replaced by http://www.w3.org/1999/02/22-rdf-syntax-ns#type IRI.
 func Unmarshal(data []byte, v interface{}) error {
-	return (&Config{}).Unmarshal(data, v)
+	return (&Config{ResolveURLs: true}).Unmarshal(data, v)
 }
 
-func unmarshal(s *scanner.Scanner, v reflect.Value) error {
+func unmarshal(s source, v reflect.Value) error {
+	// the value can consume all the triples on its own
+	if u, ok := unmarshaler[Unmarshaler](v, unmarshalerType); ok {
+		return unmarshalTriples(s, u)
+	}
+
 	switch v.Kind() {
 	case reflect.Ptr:
 		return unmarshal(s, v.Elem())
 	case reflect.Slice:
+		if implements(v.Type().Elem(), unmarshalerType) {
+			return unmarshalTripleGroups(s, v)
+		}
+		if isResource(v.Type().Elem()) || v.Type().Elem().Kind() == reflect.Pointer && isResource(v.Type().Elem().Elem()) {
+			return unmarshalResources(s, v)
+		}
 		return unmarshalSlice(s, v)
 	case reflect.Struct:
+		if isResource(v.Type()) {
+			return unmarshalResources(s, v)
+		}
 		ok := s.Next()
 		if !ok {
-			return nil
+			return s.Err()
 		}
 		err, _ := unmarshalStruct(s, v)
 		return err
@@ -65,7 +86,7 @@ func unmarshal(s *scanner.Scanner, v reflect.Value) error {
 	return nil
 }
 
-func unmarshalSlice(s *scanner.Scanner, v reflect.Value) error {
+func unmarshalSlice(s source, v reflect.Value) error {
 	if v.Kind() != reflect.Slice {
 		return errors.New("value not a slice")
 	}
@@ -109,10 +130,10 @@ func unmarshalSlice(s *scanner.Scanner, v reflect.Value) error {
 		v.Set(reflect.Append(v, item))
 	}
 
-	return nil
+	return s.Err()
 }
 
-func unmarshalStruct(s *scanner.Scanner, v reflect.Value) (error, bool) {
+func unmarshalStruct(s source, v reflect.Value) (error, bool) {
 	if v.Kind() != reflect.Struct {
 		return errors.New("value not struct"), false
 	}
@@ -129,12 +150,14 @@ outer:
 			}
 		}
 		if !s.Next() {
-			return nil, false
+			return s.Err(), false
 		}
 	}
 
 	numField := v.NumField()
 	_ = numField
+	// the label keeps the direction unless the struct has a field for it
+	direction := hasTag(v.Type(), "direction")
 	for i := 0; i < v.NumField(); i++ {
 		field := v.Field(i)
 
@@ -164,12 +187,37 @@ outer:
 			part = datatype
 		case "objecttype":
 			part = objecttype
-		case "base", "prefix":
+		case "base", "prefix", "graph", "direction":
 			part = -1
 		}
 
-		if part >= 0 {
+		switch {
+		case part == label && direction:
+			word, _ = rdf.SplitLanguageTag(t[label])
+		case part >= 0:
 			word = t[part]
+		case tag == "graph":
+			word = graphName(s)
+		case tag == "direction":
+			_, word = rdf.SplitLanguageTag(t[label])
+		}
+
+		// the subject can be a quoted triple as well
+		if part == subject && isQuotedTriple(field.Type()) {
+			if err := unmarshalQuoted(field, word); err != nil {
+				return err, false
+			}
+			continue
+		}
+
+		// the object can be a typed value or a value consuming its own term
+		if part == object {
+			if handled, err := unmarshalTerm(field, objectTerm(t)); handled {
+				if err != nil {
+					return err, false
+				}
+				continue
+			}
 		}
 
 		// if field is string set value
@@ -207,6 +255,17 @@ outer:
 	return nil, true
 }
 
+// hasTag reports whether a field of the struct is tagged by the tag.
+func hasTag(t reflect.Type, tag string) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Tag.Get("turtle") == tag {
+			return true
+		}
+	}
+
+	return false
+}
+
 func isMap(value reflect.Type) bool {
 	return value.Key().Kind() == reflect.String && value.Elem().Kind() == reflect.String
 }
@@ -1,6 +1,7 @@
 package turtle_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/nvkp/turtle"
@@ -157,7 +158,8 @@ func TestUnmarshalBaseAndPrefixes(t *testing.T) {
 </person/Mark_Twain> </relation/author> books:Huckleberry_Finn .`)
 
 	c := turtle.Config{
-		Base: "http://example.org/",
+		ResolveURLs: true,
+		Base:        "http://example.org/",
 		Prefixes: map[string]string{
 			"books": "https://amazon.com/",
 		},
@@ -173,13 +175,77 @@ func TestUnmarshalBaseAndPrefixes(t *testing.T) {
 func TestUnmarshalSubjectURLTrailingSlash(t *testing.T) {
 	var target triple
 	data := []byte(`<.> <http://example.org/relation/author> <http://example.org/books/Huckleberry_Finn> .`)
+	// the path of a base without one is "/" when resolving against it
 	expected := triple{
-		Subject:   "http://example.org",
+		Subject:   "http://example.org/",
 		Predicate: "http://example.org/relation/author",
 		Object:    "http://example.org/books/Huckleberry_Finn",
 	}
 
-	err := (&turtle.Config{Base: "http://example.org"}).Unmarshal(data, &target)
+	err := (&turtle.Config{ResolveURLs: true, Base: "http://example.org"}).Unmarshal(data, &target)
 	assert.NoError(t, err, "function Unmarshal should have returned no error")
 	assert.Equal(t, expected, target, "function Unmarshal should have assigned correct values to the target triple")
 }
+
+func TestUnmarshalSyntaxError(t *testing.T) {
+	target := make([]triple, 0)
+	data := []byte(`<http://example.org/person/Mark_Twain> <http://example.org/relation/author> <http://example.org/books/Huckleberry_Finn> .
+<http://example.org/person/Mark_Twain> <http://example.org/relation/author> .`)
+
+	err := turtle.Unmarshal(data, &target)
+
+	var syntaxErr *turtle.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Fatalf("expected a syntax error, got: %v", err)
+	}
+	assert.Equal(t, 2, syntaxErr.Line, "syntax error should point at the correct line")
+	assert.Equal(t, 77, syntaxErr.Column, "syntax error should point at the correct column")
+	assert.Equal(t, ".", syntaxErr.Token, "syntax error should contain the offending token")
+	assert.Equal(t, "object", syntaxErr.Expected, "syntax error should describe what was expected")
+}
+
+func TestUnmarshalStrict(t *testing.T) {
+	data := []byte(`@prefix book: <http://example.org/books/> .
+<http://example.org/person/Mark_Twain> <http://example.org/relation/author> book:Huckleberry_Finn .
+<http://example.org/person/Mark_Twain> <http://example.org/relation/author> person:Tom_Sawyer .`)
+
+	// lenient unmarshaling keeps the undeclared prefixed name as it is
+	target := make([]triple, 0)
+	err := turtle.Unmarshal(data, &target)
+	assert.NoError(t, err, "lenient unmarshaling should have accepted the data")
+	assert.Equal(t, 2, len(target), "lenient unmarshaling should have read both triples")
+
+	target = make([]triple, 0)
+	err = (&turtle.Config{ResolveURLs: true, Strict: true}).Unmarshal(data, &target)
+
+	var syntaxErr *turtle.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Fatalf("expected a syntax error, got: %v", err)
+	}
+	assert.Equal(t, 3, syntaxErr.Line, "syntax error should point at the correct line")
+	assert.Equal(t, "person:Tom_Sawyer", syntaxErr.Token, "syntax error should contain the offending token")
+	assert.Equal(t, "declared prefix", syntaxErr.Expected, "syntax error should describe what was expected")
+	assert.Equal(t, []triple{{
+		Subject:   "http://example.org/person/Mark_Twain",
+		Predicate: "http://example.org/relation/author",
+		Object:    "http://example.org/books/Huckleberry_Finn",
+	}}, target, "strict unmarshaling should have kept the triples before the error")
+}
+
+func TestUnmarshalVerbatim(t *testing.T) {
+	data := []byte(`@prefix books: <https://amazon.com/> .
+</person/Mark_Twain> </relation/author> books:Huckleberry_Finn .`)
+
+	c := turtle.Config{
+		Base: "http://example.org/",
+	}
+
+	var target triple
+	err := c.Unmarshal(data, &target)
+	assert.NoError(t, err, "function Unmarshal should have returned no error")
+	assert.Equal(t, triple{
+		Subject:   "/person/Mark_Twain",
+		Predicate: "/relation/author",
+		Object:    "books:Huckleberry_Finn",
+	}, target, "IRIs should have been kept as written")
+}